@@ -0,0 +1,459 @@
+package report
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// bidiClass - the simplified bidirectional character types
+type bidiClass int
+
+const (
+	bidiN  bidiClass = iota // other neutral (punctuation, symbols)
+	bidiWS                  // white-space
+	bidiL                   // strong left-to-right
+	bidiR                   // strong right-to-left
+	bidiAL                  // strong right-to-left of the Arabic letters
+	bidiEN                  // European number
+	bidiAN                  // Arabic number (the European numbers after Arabic letters)
+	bidiES                  // European number separator (plus and minus signs)
+	bidiET                  // European number terminator (currency, percent and degree signs)
+	bidiCS                  // common number separator (comma, full stop, colon, slash)
+)
+
+// rtlScripts - the right-to-left scripts
+var rtlScripts = []*unicode.RangeTable{
+	unicode.Hebrew, unicode.Arabic, unicode.Syriac, unicode.Thaana, unicode.Nko,
+	unicode.Samaritan, unicode.Mandaic,
+}
+
+// alScripts - the right-to-left scripts of the Arabic letter type
+var alScripts = []*unicode.RangeTable{
+	unicode.Arabic, unicode.Syriac, unicode.Thaana,
+}
+
+// bidiMirror - the mirrored pairs of the bracket characters
+var bidiMirror = map[rune]rune{
+	'(': ')', ')': '(', '[': ']', ']': '[', '{': '}', '}': '{', '<': '>', '>': '<',
+	'«': '»', '»': '«', '‹': '›', '›': '‹',
+}
+
+// bidiBrackets - the paired bracket characters: opening bracket -> closing bracket
+var bidiBrackets = map[rune]rune{
+	'(': ')', '[': ']', '{': '}',
+}
+
+// runeClass returns the bidirectional type of a rune
+func runeClass(r rune) bidiClass {
+	switch {
+	case unicode.IsDigit(r):
+		return bidiEN
+	case r == '\u00a0':
+		return bidiCS
+	case unicode.IsSpace(r):
+		return bidiWS
+	case r == '+' || r == '-' || r == '\u2212':
+		return bidiES
+	case r == ',' || r == '.' || r == ':' || r == '/':
+		return bidiCS
+	case r == '#' || r == '%' || r == '°' || r == '‰' || r == '±' || unicode.Is(unicode.Sc, r):
+		return bidiET
+	case unicode.In(r, rtlScripts...):
+		if unicode.IsLetter(r) || unicode.IsMark(r) {
+			if unicode.In(r, alScripts...) {
+				return bidiAL
+			}
+			return bidiR
+		}
+		return bidiN
+	case unicode.IsLetter(r):
+		return bidiL
+	}
+	return bidiN
+}
+
+// strongDir returns the strong direction of a resolved type: bidiL, bidiR or bidiN.
+// The numbers count as right-to-left characters (N1).
+func strongDir(class bidiClass) bidiClass {
+	switch class {
+	case bidiL:
+		return bidiL
+	case bidiR, bidiAL, bidiEN, bidiAN:
+		return bidiR
+	}
+	return bidiN
+}
+
+// wordClass returns the bidirectional type of a word: the type of the first strong or number character
+func wordClass(word string) bidiClass {
+	for _, r := range word {
+		switch class := runeClass(r); class {
+		case bidiL, bidiR, bidiAL, bidiEN:
+			return class
+		}
+	}
+	return bidiN
+}
+
+// isRTL returns the paragraph direction: true if the direction value is "rtl", or the direction is
+// not set ("" or "auto") and the first strong type of the classes is right-to-left.
+func isRTL(classes []bidiClass, direction string) bool {
+	switch direction {
+	case "rtl":
+		return true
+	case "ltr":
+		return false
+	}
+	for _, class := range classes {
+		switch class {
+		case bidiL:
+			return false
+		case bidiR, bidiAL:
+			return true
+		}
+	}
+	return false
+}
+
+// textDirection returns the resolved direction ("ltr" or "rtl") of a paragraph by the direction value
+// ("ltr", "rtl" or "" auto). The auto direction is the direction of the first strong character.
+func textDirection(txtStr, direction string) string {
+	classes := make([]bidiClass, 0)
+	for _, r := range txtStr {
+		classes = append(classes, runeClass(r))
+	}
+	if isRTL(classes, direction) {
+		return "rtl"
+	}
+	return "ltr"
+}
+
+// bidiPairs returns the logical indexes of the paired brackets ordered by the opening brackets (BD16)
+func bidiPairs(runes []rune, classes []bidiClass) (pairs [][2]int) {
+	type opening struct {
+		index   int
+		closing rune
+	}
+	stack := make([]opening, 0)
+	for i, r := range runes {
+		if classes[i] != bidiN {
+			continue
+		}
+		if closing, found := bidiBrackets[r]; found {
+			if len(stack) == 63 {
+				break
+			}
+			stack = append(stack, opening{index: i, closing: closing})
+			continue
+		}
+		for s := len(stack) - 1; s >= 0; s-- {
+			if stack[s].closing == r {
+				pairs = append(pairs, [2]int{stack[s].index, i})
+				stack = stack[:s]
+				break
+			}
+		}
+	}
+	sort.Slice(pairs, func(a, b int) bool {
+		return pairs[a][0] < pairs[b][0]
+	})
+	return pairs
+}
+
+/*
+bidiLevels resolves the embedding levels of the classes by a simplified version of the Unicode
+Bidirectional Algorithm (without explicit embeddings and isolates). The runes are used to pair
+the brackets, they can be nil (e.g. the classes of words):
+  - the numbers after Arabic letters are Arabic numbers (W2, W3)
+  - a single separator between two numbers and the terminators next to a European number
+    join the number, the other separators and terminators are neutrals (W4, W5, W6)
+  - the European numbers after a left-to-right character are left-to-right characters (W7)
+  - the paired brackets take the direction of their content or context (N0)
+  - the neutrals between characters of the same direction take that direction,
+    otherwise the paragraph direction (N1, N2)
+  - the right-to-left characters and the numbers are placed at a higher level (I1, I2)
+  - the trailing white-spaces take the paragraph level (L1)
+*/
+func bidiLevels(runes []rune, classes []bidiClass, rtl bool) []int {
+	base, baseDir := 0, bidiL
+	if rtl {
+		base, baseDir = 1, bidiR
+	}
+	types := make([]bidiClass, len(classes))
+	copy(types, classes)
+	// W2, W3: the European numbers after Arabic letters
+	lastStrong := baseDir
+	for i, class := range types {
+		switch class {
+		case bidiL, bidiR, bidiAL:
+			lastStrong = class
+		case bidiEN:
+			if lastStrong == bidiAL {
+				types[i] = bidiAN
+			}
+		}
+	}
+	for i, class := range types {
+		if class == bidiAL {
+			types[i] = bidiR
+		}
+	}
+	// W4: a single separator between two numbers of the same type
+	for i := 1; i < len(types)-1; i++ {
+		before, after := types[i-1], types[i+1]
+		switch {
+		case types[i] == bidiES && before == bidiEN && after == bidiEN:
+			types[i] = bidiEN
+		case types[i] == bidiCS && before == after && (before == bidiEN || before == bidiAN):
+			types[i] = before
+		}
+	}
+	// W5: the sequences of terminators next to a European number
+	for i := 0; i < len(types); i++ {
+		if types[i] != bidiET {
+			continue
+		}
+		start := i
+		for i < len(types) && types[i] == bidiET {
+			i++
+		}
+		if (start > 0 && types[start-1] == bidiEN) || (i < len(types) && types[i] == bidiEN) {
+			for n := start; n < i; n++ {
+				types[n] = bidiEN
+			}
+		}
+		i--
+	}
+	// W6, W7: the remaining separators and terminators are neutrals,
+	// the European numbers after a left-to-right character are left-to-right
+	lastStrong = baseDir
+	for i, class := range types {
+		switch class {
+		case bidiES, bidiET, bidiCS:
+			types[i] = bidiN
+		case bidiL, bidiR:
+			lastStrong = class
+		case bidiEN:
+			if lastStrong == bidiL {
+				types[i] = bidiL
+			}
+		}
+	}
+	// N0: the paired brackets
+	if runes != nil {
+		for _, pair := range bidiPairs(runes, classes) {
+			inner := bidiN
+			for n := pair[0] + 1; n < pair[1]; n++ {
+				if dir := strongDir(types[n]); dir == baseDir {
+					inner = dir
+					break
+				} else if dir != bidiN {
+					inner = dir
+				}
+			}
+			if inner == bidiN {
+				continue
+			}
+			if inner != baseDir {
+				// the context before the opening bracket
+				context := baseDir
+				for n := pair[0] - 1; n >= 0; n-- {
+					if dir := strongDir(types[n]); dir != bidiN {
+						context = dir
+						break
+					}
+				}
+				if context != inner {
+					inner = baseDir
+				}
+			}
+			types[pair[0]], types[pair[1]] = inner, inner
+		}
+	}
+	// N1, N2: the sequences of neutrals
+	for i := 0; i < len(types); i++ {
+		if strongDir(types[i]) != bidiN {
+			continue
+		}
+		start := i
+		for i < len(types) && strongDir(types[i]) == bidiN {
+			i++
+		}
+		before, after := baseDir, baseDir
+		if start > 0 {
+			before = strongDir(types[start-1])
+		}
+		if i < len(types) {
+			after = strongDir(types[i])
+		}
+		dir := baseDir
+		if before == after {
+			dir = before
+		}
+		for n := start; n < i; n++ {
+			types[n] = dir
+		}
+		i--
+	}
+	// I1, I2: the resolved levels
+	levels := make([]int, len(types))
+	for i, class := range types {
+		switch {
+		case class == bidiR && base == 0:
+			levels[i] = 1
+		case (class == bidiEN || class == bidiAN) && base == 0:
+			levels[i] = 2
+		case class == bidiR:
+			levels[i] = 1
+		case base == 1:
+			levels[i] = 2
+		default:
+			levels[i] = 0
+		}
+	}
+	// L1: the trailing white-spaces
+	for i := len(classes) - 1; i >= 0 && classes[i] == bidiWS; i-- {
+		levels[i] = base
+	}
+	return levels
+}
+
+// bidiOrder returns the visual order (the logical indexes) of the levels (L2)
+func bidiOrder(levels []int) []int {
+	order := make([]int, len(levels))
+	maxLevel, minOdd := 0, 0
+	for i, level := range levels {
+		order[i] = i
+		if level > maxLevel {
+			maxLevel = level
+		}
+		if level%2 == 1 && (minOdd == 0 || level < minOdd) {
+			minOdd = level
+		}
+	}
+	if minOdd == 0 {
+		return order
+	}
+	for level := maxLevel; level >= minOdd; level-- {
+		for i := 0; i < len(levels); i++ {
+			if levels[order[i]] < level {
+				continue
+			}
+			end := i
+			for end < len(levels) && levels[order[end]] >= level {
+				end++
+			}
+			for a, b := i, end-1; a < b; a, b = a+1, b-1 {
+				order[a], order[b] = order[b], order[a]
+			}
+			i = end
+		}
+	}
+	return order
+}
+
+// hasRTL returns true if the text contains right-to-left characters
+func hasRTL(txtStr string) bool {
+	return strings.IndexFunc(txtStr, func(r rune) bool {
+		class := runeClass(r)
+		return class == bidiR || class == bidiAL
+	}) > -1
+}
+
+// bidiText returns a line of text in visual order. The direction values: "ltr", "rtl" or "" (auto).
+// The brackets of the right-to-left runs are mirrored.
+func bidiText(txtStr, direction string) string {
+	if direction != "rtl" && !hasRTL(txtStr) {
+		return txtStr
+	}
+	runes := []rune(txtStr)
+	classes := make([]bidiClass, len(runes))
+	for i, r := range runes {
+		classes[i] = runeClass(r)
+	}
+	levels := bidiLevels(runes, classes, isRTL(classes, direction))
+	var visual strings.Builder
+	for _, index := range bidiOrder(levels) {
+		r := runes[index]
+		if mirror, found := bidiMirror[r]; found && levels[index]%2 == 1 {
+			r = mirror
+		}
+		visual.WriteRune(r)
+	}
+	return visual.String()
+}
+
+// paragraphDirection returns the resolved direction ("ltr" or "rtl") of the HTML paragraph starting with the
+// first word by the direction value ("ltr", "rtl" or "" auto). The paragraph ends at the first line break.
+func paragraphDirection(words []htmlWord, direction string) string {
+	classes := make([]bidiClass, 0)
+	for _, word := range words {
+		if word.brk {
+			break
+		}
+		classes = append(classes, wordClass(word.text))
+	}
+	if isRTL(classes, direction) {
+		return "rtl"
+	}
+	return "ltr"
+}
+
+// bidiWords returns the words of an HTML text line in visual order. The direction values: "ltr", "rtl" or "" (auto).
+func bidiWords(words []htmlWord, direction string) []htmlWord {
+	classes := make([]bidiClass, len(words))
+	for i, word := range words {
+		classes[i] = wordClass(word.text)
+	}
+	rtl, mixed := isRTL(classes, direction), false
+	for _, word := range words {
+		mixed = mixed || hasRTL(word.text)
+	}
+	if !rtl && !mixed {
+		return words
+	}
+	levels := bidiLevels(nil, classes, rtl)
+	order := bidiOrder(levels)
+	visual := make([]htmlWord, len(words))
+	for i, index := range order {
+		word := words[index]
+		wordDirection := "ltr"
+		if levels[index]%2 == 1 {
+			wordDirection = "rtl"
+		}
+		word.text = bidiText(word.text, wordDirection)
+		if i > 0 {
+			// the space before the word is the space between the logical neighbours
+			switch order[i-1] - index {
+			case 1:
+				word.space = words[order[i-1]].space
+			case -1:
+				word.space = words[index].space
+			default:
+				word.space = true
+			}
+		} else {
+			word.space = false
+		}
+		visual[i] = word
+	}
+	return visual
+}
+
+// lineStart returns the alignment of the start of the lines: "R" for the right-to-left direction, otherwise "L"
+func lineStart(direction string) string {
+	if direction == "rtl" {
+		return "R"
+	}
+	return "L"
+}
+
+// defaultAlign returns the align value, or the start of the lines if the align value is not set.
+// The right-to-left direction mirrors only the default alignment, the set align values are kept.
+func defaultAlign(alignStr, direction string) string {
+	if alignStr == "" {
+		return lineStart(direction)
+	}
+	return alignStr
+}
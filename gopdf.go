@@ -3,10 +3,13 @@ package report
 import (
 	"image"
 	"io"
+	"os"
 	"path"
 	"strings"
+	"unicode"
 
 	"github.com/signintech/gopdf"
+	"github.com/signintech/gopdf/fontmaker/core"
 )
 
 type genGoPDF struct {
@@ -16,11 +19,17 @@ type genGoPDF struct {
 	fontFamily   string
 	fontStyle    string
 	fontSize     float64
+	decoration   string
+	metrics      map[string]fontMetrics
+	fillColor    [3]uint8
+	textColor    [3]uint8
 	leftMargin   float64
 	topMargin    float64
 	rightMargin  float64
 	bottomMargin float64
 	splitText    func(txt string, w float64) []string
+	fallbacks    func(styleStr string) []fontFace
+	shape        func(txt string) string
 	onPage       func()
 	pageSize     gopdf.Rect
 }
@@ -33,6 +42,26 @@ var sizeMap = map[string]gopdf.Rect{
 	"legal":  *gopdf.PageSizeLegal,
 }
 
+// fontFace - a registered font family and style
+type fontFace struct {
+	family string
+	style  string
+}
+
+// fontMetrics - the vertical metrics of a font in font size units
+type fontMetrics struct {
+	ascent, descent, underlinePosition, underlineThickness, xHeight float64
+}
+
+// defaultMetrics - the font metrics of a font without parsed metric values
+var defaultMetrics = fontMetrics{ascent: 0.8, descent: -0.2, underlinePosition: -0.1, underlineThickness: 0.05, xHeight: 0.5}
+
+// fontRun - a part of a text printed with the same font face
+type fontRun struct {
+	text string
+	face fontFace
+}
+
 func init() {
 	registerGenerators("gopdf", &genGoPDF{})
 }
@@ -40,6 +69,9 @@ func init() {
 func (gen *genGoPDF) Init(rpt *Report) {
 	gen.fontFamily = rpt.FontFamily
 	gen.fontSize = rpt.FontSize
+	gen.decoration = ""
+	gen.metrics = make(map[string]fontMetrics)
+	gen.fillColor, gen.textColor = [3]uint8{}, [3]uint8{}
 	gen.pdf = gopdf.GoPdf{}
 	gen.format = strings.ToLower(rpt.format)
 	gen.orientation = strings.ToLower(rpt.orientation)
@@ -50,15 +82,16 @@ func (gen *genGoPDF) Init(rpt *Report) {
 	gen.splitText = func(txt string, w float64) []string {
 		return rpt.wrapTextLines(txt, w)
 	}
+	gen.fallbacks = func(styleStr string) []fontFace {
+		return rpt.getFallbackFonts(styleStr)
+	}
+	gen.shape = func(txt string) string {
+		return rpt.shapeText(txt)
+	}
 	gen.onPage = func() {
 		rpt.onPage()
 	}
-	size := sizeMap[strings.ToLower(gen.format)]
-	if gen.orientation == "p" {
-		gen.pageSize = size
-	} else {
-		gen.pageSize = gopdf.Rect{W: size.H, H: size.W}
-	}
+	gen.SetPageSize(gen.format, gen.orientation)
 	gen.pdf.Start(gopdf.Config{
 		Unit:     1,
 		PageSize: gen.pageSize,
@@ -77,7 +110,24 @@ func (gen *genGoPDF) PageNo() int {
 
 // AddPage adds a new page to the document
 func (gen *genGoPDF) AddPage() {
-	gen.pdf.AddPage()
+	pageSize := gen.pageSize
+	gen.pdf.AddPageWithOption(gopdf.PageOption{PageSize: &pageSize})
+}
+
+// SetPageSize sets the format and the orientation of the next pages
+func (gen *genGoPDF) SetPageSize(format, orientation string) {
+	gen.format = strings.ToLower(format)
+	gen.orientation = strings.ToLower(orientation)
+	size, found := sizeMap[gen.format]
+	if !found {
+		width, height, _ := getPageFormat(gen.format)
+		size = gopdf.Rect{W: width, H: height}
+	}
+	if gen.orientation == "p" {
+		gen.pageSize = size
+	} else {
+		gen.pageSize = gopdf.Rect{W: size.H, H: size.W}
+	}
 }
 
 // AddImage draws an image
@@ -101,20 +151,84 @@ func (gen *genGoPDF) AddImage(image *Image, x, y float64, options IM) {
 }
 
 // AddFont imports a font and makes it available
-func (gen *genGoPDF) AddFont(familyStr, styleStr, fileStr string, rd io.Reader) {
+func (gen *genGoPDF) AddFont(familyStr, styleStr, fileStr string, rd io.Reader) error {
 	style := func(check string, value int) int {
 		if strings.Contains(styleStr, check) {
 			return value
 		}
 		return gopdf.Regular
 	}
+	var data []byte
+	var err error
 	if rd != nil {
-		_ = gen.pdf.AddTTFFontByReaderWithOption(familyStr, rd,
-			gopdf.TtfOption{Style: style("B", gopdf.Bold) | style("I", gopdf.Italic)})
+		data, err = io.ReadAll(rd)
+	} else {
+		data, err = os.ReadFile(fileStr)
+	}
+	if err != nil {
+		return err
+	}
+	if err = gen.pdf.AddTTFFontDataWithOption(familyStr, data,
+		gopdf.TtfOption{Style: style("B", gopdf.Bold) | style("I", gopdf.Italic)}); err != nil {
+		return err
+	}
+	parser := core.TTFParser{}
+	if err = parser.ParseFontData(data); err == nil && parser.UnitsPerEm() > 0 {
+		unit := float64(parser.UnitsPerEm())
+		gen.metrics[familyStr+"|"+styleStr] = fontMetrics{
+			ascent: float64(parser.TypoAscender()) / unit, descent: float64(parser.TypoDescender()) / unit,
+			underlinePosition: float64(parser.UnderlinePosition()) / unit, underlineThickness: float64(parser.UnderlineThickness()) / unit,
+			xHeight: float64(parser.XHeight()) / unit,
+		}
+	}
+	return nil
+}
+
+// currentMetrics returns the metrics of the current font
+func (gen *genGoPDF) currentMetrics() fontMetrics {
+	if metrics, found := gen.metrics[gen.fontFamily+"|"+gen.fontStyle]; found {
+		return metrics
+	}
+	return defaultMetrics
+}
+
+// splitStyle returns the font style ("B", "I") and the text decoration ("U" underline, "S" strike-through)
+// values of a style string
+func splitStyle(styleStr string) (fontStyle, decoration string) {
+	for _, style := range styleStr {
+		switch style {
+		case 'B', 'I':
+			fontStyle += string(style)
+		case 'U', 'S':
+			decoration += string(style)
+		}
+	}
+	return fontStyle, decoration
+}
+
+// Decorate draws the underline and strike-through lines of the current font style with the baseline at (x, y).
+// The line position and thickness scale with the font size.
+func (gen *genGoPDF) Decorate(x, y, width float64) {
+	if gen.decoration == "" || width <= 0 {
 		return
 	}
-	_ = gen.pdf.AddTTFFontWithOption(familyStr, fileStr,
-		gopdf.TtfOption{Style: style("B", gopdf.Bold) | style("I", gopdf.Italic)})
+	metrics := gen.currentMetrics()
+	thickness := metrics.underlineThickness * gen.fontSize
+	if thickness <= 0 {
+		thickness = defaultMetrics.underlineThickness * gen.fontSize
+	}
+	gen.pdf.SetFillColor(gen.textColor[0], gen.textColor[1], gen.textColor[2])
+	if strings.Contains(gen.decoration, "U") {
+		position := metrics.underlinePosition * gen.fontSize
+		if position >= 0 {
+			position = defaultMetrics.underlinePosition * gen.fontSize
+		}
+		gen.pdf.RectFromUpperLeftWithStyle(x, y-position-thickness/2, width, thickness, "F")
+	}
+	if strings.Contains(gen.decoration, "S") {
+		gen.pdf.RectFromUpperLeftWithStyle(x, y-metrics.xHeight*gen.fontSize/2-thickness/2, width, thickness, "F")
+	}
+	gen.pdf.SetFillColor(gen.fillColor[0], gen.fillColor[1], gen.fillColor[2])
 }
 
 // GetFontSize returns the size of the current font in points.
@@ -130,10 +244,12 @@ func (gen *genGoPDF) SetFont(familyStr, styleStr string, size float64) {
 	if size == 0 {
 		size = gen.fontSize
 	}
-	err := gen.pdf.SetFont(familyStr, styleStr, int(size))
+	fontStyle, decoration := splitStyle(styleStr)
+	err := gen.pdf.SetFont(familyStr, fontStyle, int(size))
 	if err == nil {
 		gen.fontFamily = familyStr
-		gen.fontStyle = styleStr
+		gen.fontStyle = fontStyle
+		gen.decoration = decoration
 		gen.fontSize = size
 	}
 }
@@ -143,15 +259,108 @@ func (gen *genGoPDF) SetFontSize(size float64) {
 	if err := gen.pdf.SetFont(gen.fontFamily, gen.fontStyle, int(size)); err != nil {
 		return
 	}
+	gen.fontSize = size
 }
 
-// GetTextWidth returns the length of a string in user units.
+// GetTextWidth returns the length of a string in user units. The text is shaped by the report Shaper.
 func (gen *genGoPDF) GetTextWidth(s string) float64 {
-	w, err := gen.pdf.MeasureTextWidth(s)
-	if err != nil {
-		return 0
+	return gen.textWidth(gen.shapeText(s))
+}
+
+// textWidth returns the length of a shaped text in user units
+func (gen *genGoPDF) textWidth(s string) float64 {
+	width := float64(0)
+	runs := gen.textRuns(s)
+	for _, run := range runs {
+		gen.setFace(run.face)
+		if w, err := gen.pdf.MeasureTextWidth(run.text); err == nil {
+			width += w
+		}
+	}
+	gen.restoreFace(runs)
+	return width
+}
+
+// shapeText returns the shaped text of the report Shaper
+func (gen *genGoPDF) shapeText(txtStr string) string {
+	if gen.shape == nil {
+		return txtStr
 	}
-	return w
+	return gen.shape(txtStr)
+}
+
+// textRuns splits the text into runs by the font faces. The runes missing from the current font
+// are printed with the first font of the fallback font chain that contains the glyph.
+func (gen *genGoPDF) textRuns(txtStr string) (runs []fontRun) {
+	primary := fontFace{family: gen.fontFamily, style: gen.fontStyle}
+	faces := []fontFace{primary}
+	if gen.fallbacks != nil {
+		faces = append(faces, gen.fallbacks(gen.fontStyle)...)
+	}
+	if len(faces) == 1 || gen.containGlyphs(txtStr) {
+		return []fontRun{{text: txtStr, face: primary}}
+	}
+	face, current := primary, primary
+	for _, r := range txtStr {
+		if !unicode.IsSpace(r) {
+			face = primary
+			for _, fallback := range faces {
+				if fallback != current {
+					gen.setFace(fallback)
+					current = fallback
+				}
+				if found, _ := gen.pdf.IsCurrFontContainGlyph(r); found {
+					face = fallback
+					break
+				}
+			}
+		}
+		if len(runs) > 0 && runs[len(runs)-1].face == face {
+			runs[len(runs)-1].text += string(r)
+		} else {
+			runs = append(runs, fontRun{text: string(r), face: face})
+		}
+	}
+	gen.setFace(primary)
+	return runs
+}
+
+// containGlyphs returns true if the current font contains all glyphs of the text
+func (gen *genGoPDF) containGlyphs(txtStr string) bool {
+	for _, r := range txtStr {
+		if found, _ := gen.pdf.IsCurrFontContainGlyph(r); !found && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// setFace sets the font face with the current font size
+func (gen *genGoPDF) setFace(face fontFace) {
+	fontStyle, _ := splitStyle(face.style)
+	_ = gen.pdf.SetFont(face.family, fontStyle, int(gen.fontSize))
+}
+
+// restoreFace sets back the current font after printing or measuring the font runs
+func (gen *genGoPDF) restoreFace(runs []fontRun) {
+	if len(runs) > 1 || (len(runs) == 1 && (runs[0].face.family != gen.fontFamily || runs[0].face.style != gen.fontStyle)) {
+		gen.setFace(fontFace{family: gen.fontFamily, style: gen.fontStyle})
+	}
+}
+
+// printText prints the text runs from the current position with the baseline at the current y position
+func (gen *genGoPDF) printText(txtStr string) error {
+	runs := gen.textRuns(txtStr)
+	defer gen.restoreFace(runs)
+	x, y := gen.pdf.GetX(), gen.pdf.GetY()
+	for _, run := range runs {
+		gen.setFace(run.face)
+		if err := gen.pdf.Text(run.text); err != nil {
+			return err
+		}
+	}
+	gen.Decorate(x, y, gen.pdf.GetX()-x)
+	return nil
 }
 
 // SetDrawColor defines the color used for all drawing operations
@@ -161,11 +370,13 @@ func (gen *genGoPDF) SetDrawColor(r, g, b int) {
 
 // SetFillColor defines the color used for all filling operations
 func (gen *genGoPDF) SetFillColor(r, g, b int) {
+	gen.fillColor = [3]uint8{uint8(r), uint8(g), uint8(b)}
 	gen.pdf.SetFillColor(uint8(r), uint8(g), uint8(b))
 }
 
 // SetTextColor defines the color used for text.
 func (gen *genGoPDF) SetTextColor(r, g, b int) {
+	gen.textColor = [3]uint8{uint8(r), uint8(g), uint8(b)}
 	gen.pdf.SetTextColor(uint8(r), uint8(g), uint8(b))
 }
 
@@ -192,7 +403,7 @@ func (gen *genGoPDF) Text(txtStr string, pageBreak float64) {
 	if tw > cw {
 		txt := gen.splitText(txtStr, cw)[0]
 		checkBreak(lineHt)
-		if err := gen.pdf.Text(txt); err != nil {
+		if err := gen.printText(bidiText(gen.shapeText(txt), "")); err != nil {
 			return
 		}
 		txtStr = strings.TrimLeft(txtStr[len(txt)-1:], " ")
@@ -201,13 +412,13 @@ func (gen *genGoPDF) Text(txtStr string, pageBreak float64) {
 		for i := 0; i < len(lines); i++ {
 			gen.Ln(lineHt)
 			checkBreak(lineHt)
-			if err := gen.pdf.Text(lines[i]); err != nil {
+			if err := gen.printText(bidiText(gen.shapeText(lines[i]), "")); err != nil {
 				return
 			}
 		}
 	} else {
 		checkBreak(lineHt)
-		if err := gen.pdf.Text(txtStr); err != nil {
+		if err := gen.printText(bidiText(gen.shapeText(txtStr), "")); err != nil {
 			return
 		}
 	}
@@ -274,7 +485,21 @@ func (gen *genGoPDF) currentWidth() float64 {
 	return pw - gen.rightMargin - gen.pdf.GetX()
 }
 
+// cellPadding returns the paddings of a cell. The "padding" option is the padding of all sides, the
+// "paddingLeft", "paddingTop", "paddingRight" and "paddingBottom" options are optional, their default value is the "padding" option.
+func cellPadding(options IM) (left, top, right, bottom float64) {
+	side := func(key string) float64 {
+		if value, found := options[key].(float64); found {
+			return value
+		}
+		return ToFloat(options["padding"], 0)
+	}
+	return side("paddingLeft"), side("paddingTop"), side("paddingRight"), side("paddingBottom")
+}
+
 // Cell prints a rectangular cell with optional borders, background color and character string.
+// The text is shaped by the report Shaper and printed in visual order by the "directionStr" option
+// ("ltr", "rtl" or "" auto).
 func (gen *genGoPDF) Cell(options IM) {
 	if options["w"].(float64) == 0 {
 		options["w"] = gen.currentWidth()
@@ -287,35 +512,75 @@ func (gen *genGoPDF) Cell(options IM) {
 	if options["borderStr"] != "" {
 		gen.setBorder(options["borderStr"].(string), options["w"].(float64), options["h"].(float64), cx, cy)
 	}
-	if options["alignStr"] == "L" {
-		gen.pdf.SetX(cx + options["padding"].(float64)/2)
-		if err := gen.pdf.CellWithOption(&gopdf.Rect{W: options["w"].(float64), H: options["h"].(float64)}, options["txtStr"].(string),
-			gopdf.CellOption{Align: gopdf.Left | gopdf.Middle, Float: gopdf.Right}); err != nil {
-			return
-		}
-		gen.pdf.SetX(cx + options["w"].(float64))
+	options["txtStr"] = bidiText(gen.shapeText(options["txtStr"].(string)), ToString(options["directionStr"], ""))
+	paddingLeft, _, paddingRight, _ := cellPadding(options)
+	switch options["alignStr"] {
+	case "L":
+		gen.cellText(cx+paddingLeft, cy, options["txtStr"].(string), options)
+	case "C":
+		tw := gen.textWidth(options["txtStr"].(string))
+		gen.cellText(cx+paddingLeft+(options["w"].(float64)-paddingLeft-paddingRight-tw)/2, cy, options["txtStr"].(string), options)
+	case "R":
+		tw := gen.textWidth(options["txtStr"].(string))
+		gen.cellText(cx+(options["w"].(float64)-tw-paddingRight), cy, options["txtStr"].(string), options)
+	case "J":
+		gen.justifyCell(cx, cy, options)
 	}
-	if options["alignStr"] == "C" {
-		if err := gen.pdf.CellWithOption(&gopdf.Rect{W: options["w"].(float64), H: options["h"].(float64)}, options["txtStr"].(string),
-			gopdf.CellOption{Align: gopdf.Center | gopdf.Middle, Float: gopdf.Right}); err != nil {
-			return
-		}
+	gen.pdf.SetXY(cx+options["w"].(float64), cy)
+	if options["ln"].(bool) {
+		gen.pdf.Br(options["h"].(float64))
+	}
+}
+
+// cellText prints the text of a cell from the x position. The vertical position of the text
+// depends on the "valignStr" option: "T" (top), "M" (middle, default), "B" (bottom) or
+// "A" (the baseline of the text is placed at the "baseline" distance from the top of the cell).
+func (gen *genGoPDF) cellText(x, cy float64, txtStr string, options IM) {
+	_, paddingTop, _, paddingBottom := cellPadding(options)
+	h := options["h"].(float64)
+	valign := gopdf.Middle
+	switch ToString(options["valignStr"], "M") {
+	case "A":
+		gen.TextLine(x, cy+ToFloat(options["baseline"], h-paddingBottom), txtStr)
+		return
+	case "T":
+		valign = gopdf.Top
+	case "B":
+		valign = gopdf.Bottom
 	}
-	if options["alignStr"] == "R" {
-		tw := gen.GetTextWidth(options["txtStr"].(string))
-		gen.pdf.SetX(cx + (options["w"].(float64) - tw - options["padding"].(float64)/2))
-		if err := gen.pdf.CellWithOption(&gopdf.Rect{W: options["w"].(float64), H: options["h"].(float64)}, options["txtStr"].(string),
-			gopdf.CellOption{Align: gopdf.Left | gopdf.Middle, Float: gopdf.Right}); err != nil {
+	gen.pdf.SetXY(x, cy+paddingTop)
+	runs := gen.textRuns(txtStr)
+	defer gen.restoreFace(runs)
+	for _, run := range runs {
+		gen.setFace(run.face)
+		tw, _ := gen.pdf.MeasureTextWidth(run.text)
+		rect := &gopdf.Rect{W: tw, H: h - paddingTop - paddingBottom}
+		if err := gen.pdf.CellWithOption(rect, run.text,
+			gopdf.CellOption{Align: gopdf.Left | valign, Float: gopdf.Right}); err != nil {
 			return
 		}
-		gen.pdf.SetX(cx + options["w"].(float64))
 	}
-	if options["ln"].(bool) {
-		gen.pdf.Br(options["h"].(float64))
+	gen.Decorate(x, gen.cellBaseline(cy, options), gen.pdf.GetX()-x)
+}
+
+// cellBaseline returns the baseline position of the cell text by the "valignStr" option
+func (gen *genGoPDF) cellBaseline(cy float64, options IM) float64 {
+	_, paddingTop, _, paddingBottom := cellPadding(options)
+	h := options["h"].(float64)
+	metrics, textH := gen.currentMetrics(), h-paddingTop-paddingBottom
+	switch ToString(options["valignStr"], "M") {
+	case "A":
+		return cy + ToFloat(options["baseline"], h-paddingBottom)
+	case "T":
+		return cy + paddingTop + metrics.ascent*gen.fontSize
+	case "B":
+		return cy + paddingTop + textH + metrics.descent*gen.fontSize
 	}
+	return cy + paddingTop + textH/2 + (metrics.ascent+metrics.descent)*gen.fontSize/2
 }
 
 // MultiCell supports printing text with line breaks.
+// The "lineH" option is the distance between the lines of the text.
 func (gen *genGoPDF) MultiCell(options IM) {
 	cx := gen.pdf.GetX()
 	cy := gen.pdf.GetY()
@@ -325,15 +590,78 @@ func (gen *genGoPDF) MultiCell(options IM) {
 	if options["borderStr"] != "" {
 		gen.setBorder(options["borderStr"].(string), options["w"].(float64), options["h"].(float64), cx, cy)
 	}
-	lines := gen.splitText(options["txtStr"].(string), options["w"].(float64)-options["padding"].(float64))
+	paddingLeft, paddingTop, paddingRight, paddingBottom := cellPadding(options)
+	txtStr := strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(options["txtStr"].(string))
+	lines := make([]string, 0)
+	aligns := make([]string, 0)
+	directions := make([]string, 0)
+	for _, paragraph := range strings.Split(txtStr, "\n") {
+		// the direction of the lines is the direction of the paragraph
+		direction := textDirection(paragraph, ToString(options["directionStr"], ""))
+		plines := gen.splitText(paragraph, options["w"].(float64)-paddingLeft-paddingRight)
+		for i := 0; i < len(plines); i++ {
+			alignStr := options["alignStr"].(string)
+			// the last line of a justified paragraph is aligned to the start of the line
+			if alignStr == "J" && i == len(plines)-1 {
+				alignStr = lineStart(direction)
+			}
+			lines = append(lines, plines[i])
+			aligns = append(aligns, alignStr)
+			directions = append(directions, direction)
+		}
+	}
+	lineH := options["lineH"].(float64)
+	boxH := gen.GetFontSize() + paddingTop + paddingBottom
+	textH := boxH + float64(len(lines)-1)*lineH
+	offset := float64(0)
+	switch ToString(options["valignStr"], "T") {
+	case "M":
+		offset = (options["h"].(float64) - textH) / 2
+	case "B":
+		offset = options["h"].(float64) - textH
+	case "A":
+		offset = ToFloat(options["baseline"], 0) - (boxH - paddingBottom)
+	}
+	if offset < 0 {
+		offset = 0
+	}
 	for i := 0; i < len(lines); i++ {
-		txt := lines[i]
-		gen.pdf.SetX(cx)
+		gen.pdf.SetXY(cx, cy+offset+float64(i)*lineH)
 		gen.Cell(IM{
-			"w": options["w"].(float64), "h": options["lineH"].(float64), "padding": options["padding"].(float64),
-			"txtStr": txt, "borderStr": "", "alignStr": options["alignStr"].(string), "fill": false, "ln": true,
+			"w": options["w"].(float64), "h": boxH,
+			"paddingLeft": paddingLeft, "paddingTop": paddingTop, "paddingRight": paddingRight, "paddingBottom": paddingBottom,
+			"txtStr": lines[i], "borderStr": "", "alignStr": aligns[i], "fill": false, "ln": true,
+			"directionStr": directions[i],
 		})
 	}
+	gen.pdf.SetXY(cx, cy+offset+textH)
+}
+
+// justifyCell prints the words of the cell text spread evenly over the cell width.
+// The text decoration is drawn in one line from the first to the last word.
+func (gen *genGoPDF) justifyCell(cx, cy float64, options IM) {
+	paddingLeft, _, paddingRight, _ := cellPadding(options)
+	width := options["w"].(float64) - paddingLeft - paddingRight
+	words := strings.Fields(options["txtStr"].(string))
+	wordsWidth := float64(0)
+	for _, word := range words {
+		wordsWidth += gen.textWidth(word)
+	}
+	gap := gen.textWidth(" ")
+	if len(words) > 1 && wordsWidth < width {
+		gap = (width - wordsWidth) / float64(len(words)-1)
+	}
+	x, decoration := cx+paddingLeft, gen.decoration
+	gen.decoration = ""
+	for index, word := range words {
+		gen.cellText(x, cy, word, options)
+		x += gen.textWidth(word)
+		if index < len(words)-1 {
+			x += gap
+		}
+	}
+	gen.decoration = decoration
+	gen.Decorate(cx+paddingLeft, gen.cellBaseline(cy, options), x-cx-paddingLeft)
 }
 
 // Save2Pdf creates a PDF output.
@@ -350,6 +678,14 @@ func (gen *genGoPDF) LoadImage(img image.Image, x, y, h, w float64) error {
 	return gen.pdf.ImageFrom(img, x, y, &gopdf.Rect{H: h, W: w})
 }
 
+// TextLine prints a single line of text with the baseline at (x, y), without line breaking.
+func (gen *genGoPDF) TextLine(x, y float64, txtStr string) {
+	gen.pdf.SetXY(x, y)
+	if err := gen.printText(txtStr); err != nil {
+		return
+	}
+}
+
 func (gen *genGoPDF) SetText(x, y float64, value string) error {
 	gen.pdf.SetXY(x, y)
 	return gen.pdf.Cell(nil, value)
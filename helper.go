@@ -74,16 +74,23 @@ func basicTokenize(htmlStr string) (list []segmentType) {
 	return
 }
 
-// writeHTML prints text from the current position using the currently selected
-// font. The text can be encoded with a basic subset of HTML
-// that includes tags for italic (I), bold (B), underscore
-// (U) attributes. When the right margin is reached a line
-// break occurs and text continues from the left margin. Upon method exit, the
-// current position is left at the end of the text.
-func (rpt *Report) writeHTML(lineHt float64, htmlStr string) {
-	var boldLvl, italicLvl, underscoreLvl int
-	setStyle := func(boldAdj, italicAdj, underscoreAdj int) {
-		styleStr := ""
+// htmlWord is a word of the HTML text with the font style it is printed with.
+type htmlWord struct {
+	text       string
+	style      string
+	space      bool    // the word is preceded by white-space
+	brk        bool    // line break ("br", "p" or "div" tag) instead of a word
+	width      float64 // text width in the word style
+	spaceWidth float64
+}
+
+// getHTMLWords splits the HTML text into words and line breaks.
+// The word widths are measured with the font family and size values.
+func (rpt *Report) getHTMLWords(htmlStr, family string, size float64) (words []htmlWord) {
+	var boldLvl, italicLvl, underscoreLvl, strikeLvl int
+	styleStr := ""
+	setStyle := func(boldAdj, italicAdj, underscoreAdj, strikeAdj int) {
+		styleStr = ""
 		boldLvl += boldAdj
 		if boldLvl > 0 {
 			styleStr += "B"
@@ -96,35 +103,252 @@ func (rpt *Report) writeHTML(lineHt float64, htmlStr string) {
 		if underscoreLvl > 0 {
 			styleStr += "U"
 		}
-		rpt.Pdf.SetFont("", styleStr, 0)
+		strikeLvl += strikeAdj
+		if strikeLvl > 0 {
+			styleStr += "S"
+		}
 	}
-	list := basicTokenize(htmlStr)
-	for _, el := range list {
+	space := false
+	for _, el := range basicTokenize(htmlStr) {
 		switch el.Cat {
 		case 'T':
-			rpt.Pdf.Text(el.Str, rpt.pageBreak-rpt.footerHeight)
+			start := -1
+			for index, r := range el.Str + " " {
+				if unicode.IsSpace(r) {
+					if start > -1 {
+						words = append(words, htmlWord{text: el.Str[start:index], style: styleStr, space: space})
+						start = -1
+					}
+					space = true
+				} else if start == -1 {
+					start = index
+				}
+			}
+			space = el.Str != strings.TrimRightFunc(el.Str, unicode.IsSpace)
 		case 'O':
 			switch el.Str {
 			case "b", "strong":
-				setStyle(1, 0, 0)
+				setStyle(1, 0, 0, 0)
 			case "i", "em":
-				setStyle(0, 1, 0)
-			//case "u":
-			//	setStyle(0, 0, 1)
+				setStyle(0, 1, 0, 0)
+			case "u", "ins":
+				setStyle(0, 0, 1, 0)
+			case "s", "del", "strike":
+				setStyle(0, 0, 0, 1)
 			case "br", "p", "div":
-				rpt.Pdf.Ln(lineHt)
+				words = append(words, htmlWord{brk: true})
+				space = false
 			}
 		case 'C':
 			switch el.Str {
 			case "b", "strong":
-				setStyle(-1, 0, 0)
+				setStyle(-1, 0, 0, 0)
 			case "i", "em":
-				setStyle(0, -1, 0)
-				//case "u":
-				//setStyle(0, 0, -1)
+				setStyle(0, -1, 0, 0)
+			case "u", "ins":
+				setStyle(0, 0, -1, 0)
+			case "s", "del", "strike":
+				setStyle(0, 0, 0, -1)
+			}
+		}
+	}
+	for index := range words {
+		if !words[index].brk {
+			rpt.setPdfFont(family, words[index].style, size)
+			words[index].width = rpt.Pdf.GetTextWidth(words[index].text)
+			words[index].spaceWidth = rpt.Pdf.GetTextWidth(" ")
+		}
+	}
+	return words
+}
+
+// writeHTML prints text from the current position using the currently selected
+// font size and the "fontFamily" option. The text can be encoded with a basic subset of HTML
+// that includes tags for italic (I), bold (B), underscore
+// (U, INS) and strike-through (S, DEL, STRIKE) attributes. When the right margin is reached a line
+// break occurs and text continues from the left margin. The "left" and "right"
+// options can narrow the margins. The lines are aligned by the "align" option
+// ("L", "C", "R" or "J"), the last line of a justified paragraph is aligned to the start of the line.
+// The words of the lines are printed in visual order by the "direction" option ("ltr", "rtl" or "" auto), the auto
+// direction is resolved once per paragraph. The "rtl" direction mirrors the default alignment of an unset "align" option.
+// Upon method exit, the current position is left at the end of the text.
+// If the "virtual" option is true, the text is only measured: nothing is printed and no page is added.
+func (rpt *Report) writeHTML(lineHt float64, htmlStr string, options IM) {
+	pageWidth, _ := rpt.Pdf.GetPageSize()
+	direction := ToString(options["direction"], "")
+	alignStr := rpt.pageAlign(defaultAlign(ToString(options["align"], ""), direction))
+	family, size := ToString(options["fontFamily"], ""), rpt.Pdf.GetFontSize()
+	left := ToFloat(options["left"], rpt.LeftMargin)
+	right := ToFloat(options["right"], pageWidth-rpt.RightMargin)
+	virtual := ToBoolean(options["virtual"], false)
+	lineX := rpt.Pdf.GetX()
+	line := make([]htmlWord, 0)
+	lineWidth := float64(0)
+	// the resolved direction of the current paragraph
+	lineDirection := direction
+
+	writeLine := func(last bool) {
+		if rpt.Pdf.GetY()+lineHt > rpt.pageBreak-rpt.footerHeight && !virtual && !rpt.inBox && !rpt.Continuous {
+			leftMargin := rpt.LeftMargin
+			rpt.nextPage()
+			// the next details column is shifted by the column distance
+			left, right = left+rpt.LeftMargin-leftMargin, right+rpt.LeftMargin-leftMargin
+			rpt.Pdf.SetXY(left, rpt.Pdf.GetY()+lineHt)
+			lineX = left
+		}
+		x, gap, spaces := lineX, float64(0), 0
+		for index := 1; index < len(line); index++ {
+			if line[index].space {
+				spaces++
+			}
+		}
+		lineAlign := alignStr
+		if lineAlign == "J" && (last || spaces == 0) {
+			lineAlign = lineStart(lineDirection)
+		}
+		switch lineAlign {
+		case "C":
+			x += (right - lineX - lineWidth) / 2
+		case "R":
+			x += right - lineX - lineWidth
+		case "J":
+			gap = (right - lineX - lineWidth) / float64(spaces)
+		}
+		y := rpt.Pdf.GetY()
+		// the words are shaped once before the reordering (the word widths are measured by GetTextWidth with shaping)
+		shaped := make([]htmlWord, len(line))
+		for index, word := range line {
+			word.text = rpt.shapeText(word.text)
+			shaped[index] = word
+		}
+		// the decoration is drawn in one line under the consecutive words of the same decoration
+		runX, runEnd, runStyle, runDecoration := x, x, "", ""
+		decorate := func() {
+			if runDecoration != "" && !virtual {
+				rpt.setPdfFont(family, runStyle, size)
+				rpt.Pdf.Decorate(runX, y, runEnd-runX)
+			}
+		}
+		for index, word := range bidiWords(shaped, lineDirection) {
+			if index > 0 && word.space {
+				x += word.spaceWidth + gap
+			}
+			fontStyle, decoration := splitStyle(word.style)
+			if decoration != runDecoration {
+				decorate()
+				runX, runStyle, runDecoration = x, word.style, decoration
 			}
+			if !virtual {
+				rpt.setPdfFont(family, fontStyle, size)
+				rpt.Pdf.TextLine(x, y, word.text)
+			}
+			x += word.width
+			runEnd = x
+		}
+		decorate()
+		rpt.Pdf.SetXY(x, y)
+		line = line[:0]
+		lineWidth = 0
+	}
+	newLine := func() {
+		rpt.Pdf.SetXY(left, rpt.Pdf.GetY()+lineHt)
+		lineX = left
+	}
+
+	words := rpt.getHTMLWords(htmlStr, family, size)
+	for index, word := range words {
+		if word.brk {
+			if len(line) > 0 {
+				writeLine(true)
+			}
+			newLine()
+			continue
+		}
+		if index == 0 || words[index-1].brk {
+			lineDirection = paragraphDirection(words[index:], direction)
+		}
+		if word.width > right-left {
+			// the word is wider than the line: it is split into several words
+			rpt.setPdfFont(family, word.style, size)
+			for index, text := range rpt.wrapTextLines(word.text, right-left) {
+				part := htmlWord{text: text, style: word.style, space: word.space && index == 0,
+					width: rpt.Pdf.GetTextWidth(text), spaceWidth: word.spaceWidth}
+				if len(line) > 0 || lineX+part.width > right {
+					if len(line) > 0 {
+						writeLine(false)
+					}
+					newLine()
+				}
+				line = append(line, part)
+				lineWidth = part.width
+			}
+			continue
+		}
+		wordWidth := word.width
+		if len(line) > 0 && word.space {
+			wordWidth += word.spaceWidth
+		}
+		if lineX+lineWidth+wordWidth > right {
+			if len(line) > 0 {
+				writeLine(false)
+			}
+			newLine()
+			wordWidth = word.width
 		}
+		line = append(line, word)
+		lineWidth += wordWidth
+	}
+	if len(line) > 0 {
+		writeLine(true)
+	}
+}
+
+// fitText returns the text fitting in the width by the overflow mode:
+//   - "clip": the text is cut at the last fitting whole character (the PDF output is not clipped
+//     to the cell rectangle)
+//   - "ellipsis": the cut text ends with an ellipsis character
+//   - "shrink": the font size is reduced down to the "minFontSize" option (default 5),
+//     and the text is cut with an ellipsis if it does not fit at the minimum font size
+func (rpt *Report) fitText(text string, width float64, overflow string, options IM) string {
+	if rpt.Pdf.GetTextWidth(text) <= width {
+		return text
+	}
+	if overflow == "shrink" {
+		minFontSize := ToFloat(options["minFontSize"], _minFontSize)
+		family, style := ToString(options["fontFamily"], ""), ToString(options["fontStyle"], "")
+		for size := rpt.Pdf.GetFontSize() - 1; size >= minFontSize; size-- {
+			rpt.setPdfFont(family, style, size)
+			if rpt.Pdf.GetTextWidth(text) <= width {
+				return text
+			}
+		}
+		overflow = "ellipsis"
+	}
+	ellipsis := ""
+	if overflow == "ellipsis" {
+		ellipsis = "…"
+		if rpt.Pdf.GetTextWidth(ellipsis) == 0 {
+			ellipsis = "..."
+		}
+	}
+	// binary search of the longest fitting prefix
+	runes := []rune(text)
+	low, high := 0, len(runes)-1
+	for low < high {
+		length := (low + high + 1) / 2
+		if rpt.Pdf.GetTextWidth(string(runes[:length])+ellipsis) <= width {
+			low = length
+		} else {
+			high = length - 1
+		}
+	}
+	if low == 0 && rpt.Pdf.GetTextWidth(ellipsis) > width {
+		return ""
+	}
+	if ellipsis != "" {
+		return strings.TrimRightFunc(string(runes[:low]), unicode.IsSpace) + ellipsis
 	}
+	return string(runes[:low])
 }
 
 // wrapTextLines splits a string into multiple lines so that the text
@@ -20,6 +20,7 @@ import (
 	"image/jpeg"
 	"image/png"
 	"io"
+	"io/fs"
 	"os"
 	"path"
 	"path/filepath"
@@ -33,6 +34,7 @@ import (
 	"github.com/boombuler/barcode/ean"
 	"github.com/boombuler/barcode/qr"
 	"github.com/boombuler/barcode/twooffive"
+	"github.com/signintech/gopdf/fontmaker/core"
 )
 
 const (
@@ -46,6 +48,7 @@ const (
 	_borderColor     = uint8(0)
 	_backgroundColor = uint8(255)
 	_padding         = float64(6.4)
+	_minFontSize     = float64(5)
 	_format          = "a4"
 	_orientation     = "p"
 	_unit            = "pt"
@@ -73,11 +76,13 @@ type Generator interface {
 	PageNo() int
 	// AddPage adds a new page to the document
 	AddPage()
+	// SetPageSize sets the format and the orientation of the next pages
+	SetPageSize(format, orientation string)
 	// AddImage draws a image
 	AddImage(image *Image, x, y float64, options IM)
 	LoadImage(img image.Image, x, y, h, w float64) error
 	// AddFont imports a font and makes it available
-	AddFont(familyStr, styleStr, fileStr string, rd io.Reader)
+	AddFont(familyStr, styleStr, fileStr string, rd io.Reader) error
 	// GetFontSize returns the size of the current font in points.
 	GetFontSize() (ptSize float64)
 	// SetFont sets the font used to print character strings
@@ -96,6 +101,10 @@ type Generator interface {
 	SetProperties(rpt *Report)
 	// Text - Write prints text from the current position.
 	Text(txtStr string, pageBreak float64)
+	// TextLine prints a single line of text with the baseline at (x, y), without line breaking.
+	TextLine(x, y float64, txtStr string)
+	// Decorate draws the underline and strike-through lines of the current font style with the baseline at (x, y).
+	Decorate(x, y, width float64)
 	// Rect outputs a rectangle of width w and height h with the upper left corner positioned at point (x, y)
 	Rect(x, y, w, h float64, styleStr string)
 	// Line draws a line between points (x1, y1) and (x2, y2) using the current draw color, line width and cap style.
@@ -149,9 +158,33 @@ var propMap SM = SM{
 	"bottommargin": "BottomMargin", "bottom-margin": "BottomMargin",
 	"imagepath": "ImagePath", "image-path": "ImagePath",
 	"fontfamily": "FontFamily", "font-family": "FontFamily",
-	"page-break": "PageBreak",
+	"page-break": "PageBreak", "valign": "VAlign", "vertical-align": "VAlign",
+	"padding": "Padding", "padding-left": "PaddingLeft", "paddingleft": "PaddingLeft",
+	"padding-top": "PaddingTop", "paddingtop": "PaddingTop", "padding-right": "PaddingRight", "paddingright": "PaddingRight",
+	"padding-bottom": "PaddingBottom", "paddingbottom": "PaddingBottom", "line-height": "LineHeight", "lineheight": "LineHeight",
+	"fallback-fonts": "FallbackFonts", "fallbackfonts": "FallbackFonts",
+	"columns": "Columns", "column-gap": "ColumnGap", "columngap": "ColumnGap",
+	"mirror-margins": "MirrorMargins", "mirrormargins": "MirrorMargins", "gutter": "Gutter", "continuous": "Continuous",
+	"unit":   "Unit",
+	"format": "Format", "orientation": "Orientation", "restart-page-number": "RestartPageNumber",
+	"sheet": "Sheet", "rows": "Rows", "left": "Left", "top": "Top", "vgap": "VGap", "offset": "Offset",
+	"direction": "Direction", "dir": "Direction",
+	"text-decoration": "TextDecoration", "textdecoration": "TextDecoration",
+	"overflow": "Overflow", "min-font-size": "MinFontSize", "minfontsize": "MinFontSize",
+	"keep-together": "KeepTogether", "keeptogether": "KeepTogether",
+	"keep-with-next": "KeepWithNext", "keepwithnext": "KeepWithNext", "min-rows": "MinRows", "minrows": "MinRows",
+	"page-break-before": "PageBreakBefore", "pagebreakbefore": "PageBreakBefore",
+	"page-break-after": "PageBreakAfter", "pagebreakafter": "PageBreakAfter",
+	"position": "Position", "x": "X", "y": "Y",
+	"widths": "Widths", "colspan": "Colspan", "rowspan": "Rowspan", "wrap": "Wrap",
 }
 
+// spacingOptions - the padding and line height options of the text elements
+var spacingOptions = []string{"padding", "paddingLeft", "paddingTop", "paddingRight", "paddingBottom", "lineHeight"}
+
+// overflowModes - the valid cell overflow values ("truncate" is the alias of "clip")
+var overflowModes = SM{"": "", "clip": "clip", "truncate": "clip", "ellipsis": "ellipsis", "shrink": "shrink"}
+
 func invalidErr(etype, evalue string) string {
 	return fmt.Sprintf("invalid %s element: %s", etype, evalue)
 }
@@ -174,6 +207,30 @@ func (pi *PageItem) setPageItem(fieldname string, value interface{}) error {
 			"Visible": func(value interface{}) {
 				pi.Item.(*Row).Visible = ToString(value, "")
 			},
+			"VAlign": func(value interface{}) {
+				pi.Item.(*Row).VAlign = ToString(value, "")
+			},
+			"KeepWithNext": func(value interface{}) {
+				pi.Item.(*Row).KeepWithNext = ToBoolean(value, false)
+			},
+			"Position": func(value interface{}) {
+				pi.Item.(*Row).Position = ToString(value, "")
+			},
+			"Wrap": func(value interface{}) {
+				pi.Item.(*Row).Wrap = ToBoolean(value, false)
+			},
+			"X": func(value interface{}) {
+				pi.Item.(*Row).X = ToFloat(value, 0)
+			},
+			"Y": func(value interface{}) {
+				pi.Item.(*Row).Y = ToFloat(value, 0)
+			},
+			"PageBreakBefore": func(value interface{}) {
+				pi.Item.(*Row).PageBreakBefore = ToBoolean(value, false)
+			},
+			"PageBreakAfter": func(value interface{}) {
+				pi.Item.(*Row).PageBreakAfter = ToBoolean(value, false)
+			},
 		},
 		"cell": {
 			"Name": func(value interface{}) {
@@ -191,12 +248,36 @@ func (pi *PageItem) setPageItem(fieldname string, value interface{}) error {
 			"Align": func(value interface{}) {
 				pi.Item.(*Cell).Align = ToString(value, "L")
 			},
+			"VAlign": func(value interface{}) {
+				pi.Item.(*Cell).VAlign = ToString(value, "")
+			},
+			"Direction": func(value interface{}) {
+				pi.Item.(*Cell).Direction = ToString(value, "")
+			},
 			"Multiline": func(value interface{}) {
 				pi.Item.(*Cell).Multiline = ToBoolean(value, false)
 			},
+			"FontFamily": func(value interface{}) {
+				pi.Item.(*Cell).FontFamily = ToString(value, "")
+			},
 			"FontStyle": func(value interface{}) {
 				pi.Item.(*Cell).FontStyle = ToString(value, "")
 			},
+			"TextDecoration": func(value interface{}) {
+				pi.Item.(*Cell).TextDecoration = ToString(value, "")
+			},
+			"Overflow": func(value interface{}) {
+				pi.Item.(*Cell).Overflow = ToString(value, "")
+			},
+			"MinFontSize": func(value interface{}) {
+				pi.Item.(*Cell).MinFontSize = ToFloat(value, 0)
+			},
+			"Colspan": func(value interface{}) {
+				pi.Item.(*Cell).Colspan = int(ToInteger(value, 1))
+			},
+			"Rowspan": func(value interface{}) {
+				pi.Item.(*Cell).Rowspan = int(ToInteger(value, 1))
+			},
 			"FontSize": func(value interface{}) {
 				pi.Item.(*Cell).FontSize = ToFloat(value, pi.Item.(*Cell).FontSize)
 			},
@@ -209,6 +290,24 @@ func (pi *PageItem) setPageItem(fieldname string, value interface{}) error {
 			"BackgroundColor": func(value interface{}) {
 				pi.Item.(*Cell).BackgroundColor = ToRGBA(value, pi.Item.(*Cell).BackgroundColor)
 			},
+			"Padding": func(value interface{}) {
+				pi.Item.(*Cell).Padding = ToString(value, "")
+			},
+			"PaddingLeft": func(value interface{}) {
+				pi.Item.(*Cell).PaddingLeft = ToString(value, "")
+			},
+			"PaddingTop": func(value interface{}) {
+				pi.Item.(*Cell).PaddingTop = ToString(value, "")
+			},
+			"PaddingRight": func(value interface{}) {
+				pi.Item.(*Cell).PaddingRight = ToString(value, "")
+			},
+			"PaddingBottom": func(value interface{}) {
+				pi.Item.(*Cell).PaddingBottom = ToString(value, "")
+			},
+			"LineHeight": func(value interface{}) {
+				pi.Item.(*Cell).LineHeight = ToString(value, "")
+			},
 		},
 		"image": {
 			"Src": func(value interface{}) {
@@ -238,6 +337,35 @@ func (pi *PageItem) setPageItem(fieldname string, value interface{}) error {
 				pi.Item.(*Barcode).Extend = ToBoolean(value, false)
 			},
 		},
+		"box": {
+			"Width": func(value interface{}) {
+				pi.Item.(*Box).Width = ToString(value, "")
+			},
+			"Border": func(value interface{}) {
+				pi.Item.(*Box).Border = ToString(value, "")
+			},
+			"BorderColor": func(value interface{}) {
+				pi.Item.(*Box).BorderColor = ToRGBA(value, pi.Item.(*Box).BorderColor)
+			},
+			"BackgroundColor": func(value interface{}) {
+				pi.Item.(*Box).BackgroundColor = ToRGBA(value, pi.Item.(*Box).BackgroundColor)
+			},
+			"Padding": func(value interface{}) {
+				pi.Item.(*Box).Padding = ToString(value, "")
+			},
+			"PaddingLeft": func(value interface{}) {
+				pi.Item.(*Box).PaddingLeft = ToString(value, "")
+			},
+			"PaddingTop": func(value interface{}) {
+				pi.Item.(*Box).PaddingTop = ToString(value, "")
+			},
+			"PaddingRight": func(value interface{}) {
+				pi.Item.(*Box).PaddingRight = ToString(value, "")
+			},
+			"PaddingBottom": func(value interface{}) {
+				pi.Item.(*Box).PaddingBottom = ToString(value, "")
+			},
+		},
 		"separator": {
 			"Gap": func(value interface{}) {
 				pi.Item.(*Separator).Gap = ToFloat(value, 0)
@@ -250,6 +378,12 @@ func (pi *PageItem) setPageItem(fieldname string, value interface{}) error {
 			"PageBreak": func(value interface{}) {
 				pi.Item.(*VGap).PageBreak = ToBoolean(value, false)
 			},
+			"PageBreakBefore": func(value interface{}) {
+				pi.Item.(*VGap).PageBreakBefore = ToBoolean(value, false)
+			},
+			"PageBreakAfter": func(value interface{}) {
+				pi.Item.(*VGap).PageBreakAfter = ToBoolean(value, false)
+			},
 			"Visible": func(value interface{}) {
 				// Deprecated
 			},
@@ -264,6 +398,15 @@ func (pi *PageItem) setPageItem(fieldname string, value interface{}) error {
 			"BorderColor": func(value interface{}) {
 				pi.Item.(*HLine).BorderColor = ToRGBA(value, pi.Item.(*HLine).BorderColor)
 			},
+			"KeepWithNext": func(value interface{}) {
+				pi.Item.(*HLine).KeepWithNext = ToBoolean(value, false)
+			},
+			"PageBreakBefore": func(value interface{}) {
+				pi.Item.(*HLine).PageBreakBefore = ToBoolean(value, false)
+			},
+			"PageBreakAfter": func(value interface{}) {
+				pi.Item.(*HLine).PageBreakAfter = ToBoolean(value, false)
+			},
 			"Visible": func(value interface{}) {
 				// Deprecated
 			},
@@ -275,6 +418,42 @@ func (pi *PageItem) setPageItem(fieldname string, value interface{}) error {
 			"Value": func(value interface{}) {
 				pi.Item.(*HTML).Value = ToString(value, "")
 			},
+			"Align": func(value interface{}) {
+				pi.Item.(*HTML).Align = ToString(value, "L")
+			},
+			"FontFamily": func(value interface{}) {
+				pi.Item.(*HTML).FontFamily = ToString(value, "")
+			},
+			"Direction": func(value interface{}) {
+				pi.Item.(*HTML).Direction = ToString(value, "")
+			},
+			"Padding": func(value interface{}) {
+				pi.Item.(*HTML).Padding = ToString(value, "")
+			},
+			"PaddingLeft": func(value interface{}) {
+				pi.Item.(*HTML).PaddingLeft = ToString(value, "")
+			},
+			"PaddingTop": func(value interface{}) {
+				pi.Item.(*HTML).PaddingTop = ToString(value, "")
+			},
+			"PaddingRight": func(value interface{}) {
+				pi.Item.(*HTML).PaddingRight = ToString(value, "")
+			},
+			"PaddingBottom": func(value interface{}) {
+				pi.Item.(*HTML).PaddingBottom = ToString(value, "")
+			},
+			"LineHeight": func(value interface{}) {
+				pi.Item.(*HTML).LineHeight = ToString(value, "")
+			},
+			"KeepWithNext": func(value interface{}) {
+				pi.Item.(*HTML).KeepWithNext = ToBoolean(value, false)
+			},
+			"PageBreakBefore": func(value interface{}) {
+				pi.Item.(*HTML).PageBreakBefore = ToBoolean(value, false)
+			},
+			"PageBreakAfter": func(value interface{}) {
+				pi.Item.(*HTML).PageBreakAfter = ToBoolean(value, false)
+			},
 		},
 		"datagrid": {
 			"Name": func(value interface{}) {
@@ -289,9 +468,18 @@ func (pi *PageItem) setPageItem(fieldname string, value interface{}) error {
 			"Merge": func(value interface{}) {
 				pi.Item.(*Datagrid).Merge = ToBoolean(value, false)
 			},
+			"Direction": func(value interface{}) {
+				pi.Item.(*Datagrid).Direction = ToString(value, "")
+			},
+			"TextDecoration": func(value interface{}) {
+				pi.Item.(*Datagrid).TextDecoration = ToString(value, "")
+			},
 			"Border": func(value interface{}) {
 				pi.Item.(*Datagrid).Border = ToString(value, "1")
 			},
+			"FontFamily": func(value interface{}) {
+				pi.Item.(*Datagrid).FontFamily = ToString(value, "")
+			},
 			"FontSize": func(value interface{}) {
 				pi.Item.(*Datagrid).FontSize = ToFloat(value, pi.Item.(*Datagrid).FontSize)
 			},
@@ -310,6 +498,139 @@ func (pi *PageItem) setPageItem(fieldname string, value interface{}) error {
 			"FooterBackground": func(value interface{}) {
 				pi.Item.(*Datagrid).FooterBackground = ToRGBA(value, pi.Item.(*Datagrid).FooterBackground)
 			},
+			"Padding": func(value interface{}) {
+				pi.Item.(*Datagrid).Padding = ToString(value, "")
+			},
+			"PaddingLeft": func(value interface{}) {
+				pi.Item.(*Datagrid).PaddingLeft = ToString(value, "")
+			},
+			"PaddingTop": func(value interface{}) {
+				pi.Item.(*Datagrid).PaddingTop = ToString(value, "")
+			},
+			"PaddingRight": func(value interface{}) {
+				pi.Item.(*Datagrid).PaddingRight = ToString(value, "")
+			},
+			"PaddingBottom": func(value interface{}) {
+				pi.Item.(*Datagrid).PaddingBottom = ToString(value, "")
+			},
+			"LineHeight": func(value interface{}) {
+				pi.Item.(*Datagrid).LineHeight = ToString(value, "")
+			},
+			"KeepWithNext": func(value interface{}) {
+				pi.Item.(*Datagrid).KeepWithNext = ToBoolean(value, false)
+			},
+			"MinRows": func(value interface{}) {
+				pi.Item.(*Datagrid).MinRows = int(ToInteger(value, 0))
+			},
+			"PageBreakBefore": func(value interface{}) {
+				pi.Item.(*Datagrid).PageBreakBefore = ToBoolean(value, false)
+			},
+			"PageBreakAfter": func(value interface{}) {
+				pi.Item.(*Datagrid).PageBreakAfter = ToBoolean(value, false)
+			},
+		},
+		"section": {
+			"Format": func(value interface{}) {
+				pi.Item.(*Section).Format = ToString(value, "")
+			},
+			"Orientation": func(value interface{}) {
+				pi.Item.(*Section).Orientation = ToString(value, "")
+			},
+			"LeftMargin": func(value interface{}) {
+				pi.Item.(*Section).LeftMargin = ToString(value, "")
+			},
+			"RightMargin": func(value interface{}) {
+				pi.Item.(*Section).RightMargin = ToString(value, "")
+			},
+			"TopMargin": func(value interface{}) {
+				pi.Item.(*Section).TopMargin = ToString(value, "")
+			},
+			"BottomMargin": func(value interface{}) {
+				pi.Item.(*Section).BottomMargin = ToString(value, "")
+			},
+			"RestartPageNumber": func(value interface{}) {
+				pi.Item.(*Section).RestartPageNumber = ToBoolean(value, false)
+			},
+		},
+		"group": {
+			"KeepTogether": func(value interface{}) {
+				pi.Item.(*Group).KeepTogether = ToBoolean(value, false)
+			},
+			"PageBreakBefore": func(value interface{}) {
+				pi.Item.(*Group).PageBreakBefore = ToBoolean(value, false)
+			},
+			"PageBreakAfter": func(value interface{}) {
+				pi.Item.(*Group).PageBreakAfter = ToBoolean(value, false)
+			},
+		},
+		"table": {
+			"Width": func(value interface{}) {
+				pi.Item.(*Table).Width = ToString(value, "")
+			},
+			"Widths": func(value interface{}) {
+				pi.Item.(*Table).Widths = ToString(value, "")
+			},
+			"Border": func(value interface{}) {
+				pi.Item.(*Table).Border = ToString(value, "")
+			},
+			"KeepWithNext": func(value interface{}) {
+				pi.Item.(*Table).KeepWithNext = ToBoolean(value, false)
+			},
+			"PageBreakBefore": func(value interface{}) {
+				pi.Item.(*Table).PageBreakBefore = ToBoolean(value, false)
+			},
+			"PageBreakAfter": func(value interface{}) {
+				pi.Item.(*Table).PageBreakAfter = ToBoolean(value, false)
+			},
+		},
+		"labels": {
+			"Databind": func(value interface{}) {
+				pi.Item.(*Labels).Databind = ToString(value, "")
+			},
+			"Sheet": func(value interface{}) {
+				pi.Item.(*Labels).Sheet = ToString(value, "")
+			},
+			"Columns": func(value interface{}) {
+				pi.Item.(*Labels).Columns = int(ToInteger(value, 0))
+			},
+			"Rows": func(value interface{}) {
+				pi.Item.(*Labels).Rows = int(ToInteger(value, 0))
+			},
+			"Width": func(value interface{}) {
+				pi.Item.(*Labels).Width = ToFloat(value, 0)
+			},
+			"Height": func(value interface{}) {
+				pi.Item.(*Labels).Height = ToFloat(value, 0)
+			},
+			"Left": func(value interface{}) {
+				pi.Item.(*Labels).Left = ToFloat(value, 0)
+			},
+			"Top": func(value interface{}) {
+				pi.Item.(*Labels).Top = ToFloat(value, 0)
+			},
+			"HGap": func(value interface{}) {
+				pi.Item.(*Labels).HGap = ToFloat(value, 0)
+			},
+			"VGap": func(value interface{}) {
+				pi.Item.(*Labels).VGap = ToFloat(value, 0)
+			},
+			"Offset": func(value interface{}) {
+				pi.Item.(*Labels).Offset = int(ToInteger(value, 0))
+			},
+			"Border": func(value interface{}) {
+				pi.Item.(*Labels).Border = ToString(value, "")
+			},
+			"BorderColor": func(value interface{}) {
+				pi.Item.(*Labels).BorderColor = ToRGBA(value, pi.Item.(*Labels).BorderColor)
+			},
+			"Padding": func(value interface{}) {
+				pi.Item.(*Labels).Padding = ToString(value, "")
+			},
+		},
+		"need-space": {
+			"Height": func(value interface{}) {
+				pi.Item.(*NeedSpace).Height = ToFloat(value, 0)
+			},
 		},
 		"column": {
 			"Fieldname": func(value interface{}) {
@@ -324,6 +645,9 @@ func (pi *PageItem) setPageItem(fieldname string, value interface{}) error {
 			"Align": func(value interface{}) {
 				pi.Item.(*Column).Align = ToString(value, "L")
 			},
+			"VAlign": func(value interface{}) {
+				pi.Item.(*Column).VAlign = ToString(value, "")
+			},
 			"HeaderAlign": func(value interface{}) {
 				pi.Item.(*Column).HeaderAlign = ToString(value, "L")
 			},
@@ -333,6 +657,30 @@ func (pi *PageItem) setPageItem(fieldname string, value interface{}) error {
 			"Footer": func(value interface{}) {
 				pi.Item.(*Column).Footer = ToString(value, "")
 			},
+			"FontFamily": func(value interface{}) {
+				pi.Item.(*Column).FontFamily = ToString(value, "")
+			},
+			"TextDecoration": func(value interface{}) {
+				pi.Item.(*Column).TextDecoration = ToString(value, "")
+			},
+			"Padding": func(value interface{}) {
+				pi.Item.(*Column).Padding = ToString(value, "")
+			},
+			"PaddingLeft": func(value interface{}) {
+				pi.Item.(*Column).PaddingLeft = ToString(value, "")
+			},
+			"PaddingTop": func(value interface{}) {
+				pi.Item.(*Column).PaddingTop = ToString(value, "")
+			},
+			"PaddingRight": func(value interface{}) {
+				pi.Item.(*Column).PaddingRight = ToString(value, "")
+			},
+			"PaddingBottom": func(value interface{}) {
+				pi.Item.(*Column).PaddingBottom = ToString(value, "")
+			},
+			"LineHeight": func(value interface{}) {
+				pi.Item.(*Column).LineHeight = ToString(value, "")
+			},
 		},
 	}
 
@@ -355,7 +703,6 @@ func (rpt *Report) getPageItem(etype string) (PageItem, error) {
 			ItemType: etype,
 			Item: &Cell{
 				Border:          "",
-				Align:           "L",
 				FontStyle:       rpt.FontStyle,
 				FontSize:        rpt.FontSize,
 				TextColor:       rpt.TextColor,
@@ -364,10 +711,7 @@ func (rpt *Report) getPageItem(etype string) (PageItem, error) {
 	case "column":
 		return PageItem{
 			ItemType: etype,
-			Item: &Column{
-				Align:       "L",
-				HeaderAlign: "L",
-				FooterAlign: "L"}}, nil
+			Item:     &Column{}}, nil
 	case "datagrid":
 		return PageItem{
 			ItemType: etype,
@@ -380,6 +724,32 @@ func (rpt *Report) getPageItem(etype string) (PageItem, error) {
 				HeaderBackground: rpt.BackgroundColor,
 				FooterBackground: rpt.BackgroundColor,
 				Columns:          make([]PageItem, 0)}}, nil
+	case "group":
+		return PageItem{
+			ItemType: etype,
+			Item: &Group{
+				Elements: make([]PageItem, 0)}}, nil
+	case "section":
+		return PageItem{
+			ItemType: etype,
+			Item: &Section{
+				Elements: make([]PageItem, 0)}}, nil
+	case "table":
+		return PageItem{
+			ItemType: etype,
+			Item: &Table{
+				Border: "1",
+				Rows:   make([]PageItem, 0)}}, nil
+	case "need-space":
+		return PageItem{
+			ItemType: etype,
+			Item:     &NeedSpace{}}, nil
+	case "labels":
+		return PageItem{
+			ItemType: etype,
+			Item: &Labels{
+				BorderColor: rpt.BorderColor,
+				Elements:    make([]PageItem, 0)}}, nil
 	case "hline":
 		return PageItem{
 			ItemType: etype,
@@ -398,6 +768,13 @@ func (rpt *Report) getPageItem(etype string) (PageItem, error) {
 			ItemType: etype,
 			Item: &Row{
 				Columns: make([]PageItem, 0)}}, nil
+	case "box":
+		return PageItem{
+			ItemType: etype,
+			Item: &Box{
+				BorderColor:     rpt.BorderColor,
+				BackgroundColor: rpt.BackgroundColor,
+				Elements:        make([]PageItem, 0)}}, nil
 	case "separator":
 		return PageItem{
 			ItemType: etype,
@@ -411,11 +788,20 @@ func (rpt *Report) getPageItem(etype string) (PageItem, error) {
 }
 
 // Row - Horizontal logical group. The last element width extends up to the right margin.
+// The absolute rows of the header and the footer are printed at the same position on every page.
 type Row struct {
-	Height  float64    `xml:"height,attr" json:"height"`   //row height
-	HGap    float64    `xml:"hgap,attr" json:"hgap"`       //default gap between these two elements
-	Visible string     `xml:"visible,attr" json:"visible"` //table data source name
-	Columns []PageItem `xml:"columns,attr" json:"columns"` //Cell, Image, Barcode, Separator
+	Height          float64    `xml:"height,attr" json:"height"`                       //row height
+	HGap            float64    `xml:"hgap,attr" json:"hgap"`                           //default gap between these two elements
+	Visible         string     `xml:"visible,attr" json:"visible"`                     //table data source name
+	VAlign          string     `xml:"valign,attr" json:"valign"`                       //default vertical alignment of the row elements. Values: "" (default, no alignment), "T" or "top", "M" or "middle", "B" or "bottom", "A" or "baseline". The images, barcodes and boxes of a "baseline" row stand on the text baseline
+	Columns         []PageItem `xml:"columns,attr" json:"columns"`                     //Cell, Image, Barcode, Separator, Box
+	KeepWithNext    bool       `xml:"keep-with-next,attr" json:"keep-with-next"`       //if true, the row is printed on the same page as the beginning of the next element (default false)
+	PageBreakBefore bool       `xml:"page-break-before,attr" json:"page-break-before"` //if true, the row starts on a new page (default false)
+	PageBreakAfter  bool       `xml:"page-break-after,attr" json:"page-break-after"`   //if true, the next element starts on a new page (default false)
+	Position        string     `xml:"position,attr" json:"position"`                   //values: "" (default, the row is printed in the flow of the elements) or "absolute"
+	X               float64    `xml:"x,attr" json:"x"`                                 //the distance of an absolute row from the left edge of the page
+	Y               float64    `xml:"y,attr" json:"y"`                                 //the distance of an absolute row from the top edge of the page
+	Wrap            bool       `xml:"wrap,attr" json:"wrap"`                           //if true, the elements that do not fit in the page width are moved to a new line of the row (default false). The Height is the minimum height of the lines.
 }
 
 // Cell - Row unit
@@ -424,13 +810,27 @@ type Cell struct {
 	Value           string     `xml:"value,attr" json:"value"`                       //static text or databind value
 	Width           string     `xml:"width,attr" json:"width"`                       //number or percent value (e.g. "10" or "10%")
 	Border          string     `xml:"border,attr" json:"border"`                     //values: "0"(no border, default), "1"(all) or some or all of the following characters: "L"(left), "T"(top), "R"(right),"B"(bottom)
-	Align           string     `xml:"align,attr" json:"align"`                       //values: "L" (default) or "left", "R" or "right", "C" or "center"
+	Align           string     `xml:"align,attr" json:"align"`                       //values: "" (default: "L", or "R" for the "rtl" direction), "L" or "left", "R" or "right", "C" or "center", "J" or "justify", "I" or "inside", "O" or "outside"
+	VAlign          string     `xml:"valign,attr" json:"valign"`                     //values: "" (default: Row.VAlign), "T" or "top", "M" or "middle", "B" or "bottom", "A" or "baseline"
 	Multiline       bool       `xml:"multiline,attr" json:"multiline"`               //if true, print text with line breaks (default false)
-	FontStyle       string     `xml:"font-style,attr" json:"font-style"`             //values: "" (default), "bold", "italic", "bolditalic"
+	Direction       string     `xml:"direction,attr" json:"direction"`               //values: "" (default: Report.Direction), "ltr" or "rtl". The "rtl" mirrors the default alignment
+	FontFamily      string     `xml:"font-family,attr" json:"font-family"`           //a registered font family (default value: Report.FontFamily)
+	FontStyle       string     `xml:"font-style,attr" json:"font-style"`             //values: "" (default), "bold", "italic", "bolditalic", "underline", "strikethrough" or some of the "B", "I", "U", "S" characters
+	TextDecoration  string     `xml:"text-decoration,attr" json:"text-decoration"`   //values: "" (default) or "none", "underline", "line-through" or "underline line-through"
+	Overflow        string     `xml:"overflow,attr" json:"overflow"`                 //the single line text wider than the cell: "" (default, visible), "clip" or "truncate" (cut at the last fitting character), "ellipsis" or "shrink"
+	MinFontSize     float64    `xml:"min-font-size,attr" json:"min-font-size"`       //the minimum font size of the "shrink" overflow (default value: 5)
+	Colspan         int        `xml:"colspan,attr" json:"colspan"`                   //the number of the spanned Table columns (default 1)
+	Rowspan         int        `xml:"rowspan,attr" json:"rowspan"`                   //the number of the spanned Table rows (default 1)
 	FontSize        float64    `xml:"font-size,attr" json:"font-size"`               //Default value: Report.FontSize
 	TextColor       color.RGBA `xml:"color,attr" json:"color"`                       //JSON or XML value: in hexadecimal (e.g. #A0522D) or in decimal (e.g 10506797), default "black"
 	BorderColor     color.RGBA `xml:"border-color,attr" json:"border-color"`         //JSON or XML value: integer gray color (in range from 0 "black" to 255 "white"), default "black"
 	BackgroundColor color.RGBA `xml:"background-color,attr" json:"background-color"` //JSON or XML value: integer gray color (in range from 0 "black" to 255 "white"), default "black"
+	Padding         string     `xml:"padding,attr" json:"padding"`                   //padding of all sides (default value: Report.Padding)
+	PaddingLeft     string     `xml:"padding-left,attr" json:"padding-left"`         //default value: Padding
+	PaddingTop      string     `xml:"padding-top,attr" json:"padding-top"`           //default value: Padding
+	PaddingRight    string     `xml:"padding-right,attr" json:"padding-right"`       //default value: Padding
+	PaddingBottom   string     `xml:"padding-bottom,attr" json:"padding-bottom"`     //default value: Padding
+	LineHeight      string     `xml:"line-height,attr" json:"line-height"`           //distance between the lines of the multiline text (default value: Report.LineHeight or FontSize + vertical padding)
 }
 
 // Image - Row unit
@@ -453,6 +853,21 @@ type Barcode struct {
 	Extend       bool    `xml:"extend,attr" json:"extend"`               //barcode width extends up to the right margin (default false)
 }
 
+// Box - Row unit, a vertical stack of page elements. The elements are printed within the box
+// width and padding, and the box is never split across pages.
+type Box struct {
+	Width           string     `xml:"width,attr" json:"width"`                       //number or percent value (e.g. "10" or "10%"), the default width extends up to the right margin
+	Border          string     `xml:"border,attr" json:"border"`                     //values: "0"(no border, default), "1"(all) or some or all of the following characters: "L"(left), "T"(top), "R"(right),"B"(bottom)
+	BorderColor     color.RGBA `xml:"border-color,attr" json:"border-color"`         //JSON or XML value: integer gray color (in range from 0 "black" to 255 "white"), default "black"
+	BackgroundColor color.RGBA `xml:"background-color,attr" json:"background-color"` //JSON or XML value: integer gray color (in range from 0 "black" to 255 "white"), default "black"
+	Padding         string     `xml:"padding,attr" json:"padding"`                   //padding of all sides (default value: 0)
+	PaddingLeft     string     `xml:"padding-left,attr" json:"padding-left"`         //default value: Padding
+	PaddingTop      string     `xml:"padding-top,attr" json:"padding-top"`           //default value: Padding
+	PaddingRight    string     `xml:"padding-right,attr" json:"padding-right"`       //default value: Padding
+	PaddingBottom   string     `xml:"padding-bottom,attr" json:"padding-bottom"`     //default value: Padding
+	Elements        []PageItem `xml:"elements" json:"elements"`                      //Row, VGap, HLine, HTML, Datagrid, Table
+}
+
 // Separator - Row unit, A horizontal separator line.
 type Separator struct {
 	Gap float64 `xml:"gap,attr" json:"gap"` //distance size
@@ -460,22 +875,93 @@ type Separator struct {
 
 // VGap - a vertical gap.
 type VGap struct {
-	Height    float64 `xml:"height,attr" json:"height"`         //distance size
-	PageBreak bool    `xml:"page-break,attr" json:"page-break"` //add a new page
+	Height          float64 `xml:"height,attr" json:"height"`                       //distance size
+	PageBreak       bool    `xml:"page-break,attr" json:"page-break"`               //add a new page
+	PageBreakBefore bool    `xml:"page-break-before,attr" json:"page-break-before"` //if true, the gap starts on a new page (default false)
+	PageBreakAfter  bool    `xml:"page-break-after,attr" json:"page-break-after"`   //if true, the next element starts on a new page (default false)
+}
+
+// Labels - a sheet of labels with one label per record of the data source. The labels are printed
+// at the fixed positions of the sheet pages, and the next details element starts on a new page.
+// The sheet pages have no report header and footer. The labels start on a new sheet page unless the
+// current page is empty and has no header and footer. The elements of a label can be rows, vgaps,
+// hlines, html texts, datagrids and tables.
+// The fields of the current record are available as dictionary values: ={{databind.fieldname}}
+type Labels struct {
+	Databind    string     `xml:"databind,attr" json:"databind"`         //the []map[string]string data source name
+	Sheet       string     `xml:"sheet,attr" json:"sheet"`               //sheet format preset: "avery-l7160", "avery-l7161", "avery-l7163", "avery-l7165", "avery-l7651" (A4), "avery-5160", "avery-5163" (letter). The preset values are used for the unset values.
+	Columns     int        `xml:"columns,attr" json:"columns"`           //number of the labels in a row of the sheet
+	Rows        int        `xml:"rows,attr" json:"rows"`                 //number of the label rows of the sheet
+	Width       float64    `xml:"width,attr" json:"width"`               //label width
+	Height      float64    `xml:"height,attr" json:"height"`             //label height
+	Left        float64    `xml:"left,attr" json:"left"`                 //distance of the first label column from the left edge of the page
+	Top         float64    `xml:"top,attr" json:"top"`                   //distance of the first label row from the top edge of the page
+	HGap        float64    `xml:"hgap,attr" json:"hgap"`                 //horizontal gap between the labels
+	VGap        float64    `xml:"vgap,attr" json:"vgap"`                 //vertical gap between the labels
+	Offset      int        `xml:"offset,attr" json:"offset"`             //number of the used labels of the first sheet (default 0)
+	Border      string     `xml:"border,attr" json:"border"`             //label border, values: "0"(no border, default), "1"(all) or some or all of the following characters: "L"(left), "T"(top), "R"(right),"B"(bottom)
+	BorderColor color.RGBA `xml:"border-color,attr" json:"border-color"` //JSON or XML value: integer gray color (in range from 0 "black" to 255 "white"), default "black"
+	Padding     string     `xml:"padding,attr" json:"padding"`           //padding of all sides of the labels (default value: 0)
+	Elements    []PageItem `xml:"elements" json:"elements"`              //the label template: Row, VGap, HLine, HTML, Datagrid, Table
+}
+
+// labelSheets - label sheet presets: columns, rows, label width, label height, left, top, horizontal gap, vertical gap (mm)
+var labelSheets = map[string][8]float64{
+	"avery-l7160": {3, 7, 63.5, 38.1, 7.2, 15.1, 2.5, 0},
+	"avery-l7161": {3, 6, 63.5, 46.6, 7.2, 8.8, 2.5, 0},
+	"avery-l7163": {2, 7, 99.1, 38.1, 4.65, 15.15, 2.5, 0},
+	"avery-l7165": {2, 4, 99.1, 67.7, 4.65, 13.1, 2.5, 0},
+	"avery-l7651": {5, 13, 38.1, 21.2, 4.75, 10.7, 2.5, 0},
+	"avery-5160":  {3, 10, 66.675, 25.4, 4.7625, 12.7, 3.175, 0},
+	"avery-5163":  {2, 5, 101.6, 50.8, 3.96875, 12.7, 4.7625, 0},
+}
+
+// pageFormats - the named page formats: width and height (mm)
+var pageFormats = map[string][2]float64{
+	"a0": {841, 1189}, "a1": {594, 841}, "a2": {420, 594}, "a3": {297, 420}, "a4": {210, 297},
+	"a5": {148, 210}, "a6": {105, 148}, "a7": {74, 105},
+	"b4": {250, 353}, "b5": {176, 250}, "b6": {125, 176},
+	"c4": {229, 324}, "c5": {162, 229}, "c6": {114, 162}, "dl": {110, 220},
+	"letter": {215.9, 279.4}, "legal": {215.9, 355.6}, "tabloid": {279.4, 431.8}, "ledger": {431.8, 279.4},
+	"executive": {184.15, 266.7}, "statement": {139.7, 215.9},
+	"envelope-9": {98.425, 225.425}, "envelope-10": {104.775, 241.3}, "monarch": {98.425, 190.5},
+}
+
+// unitPt - the length units in points
+var unitPt = map[string]float64{"pt": 1, "mm": _mmPt, "cm": 10 * _mmPt, "in": 72}
+
+// NeedSpace - adds a new page if the free space of the page is less than the height.
+type NeedSpace struct {
+	Height float64 `xml:"height,attr" json:"height"` //the needed space
 }
 
 // HLine - a horizontal line.
 type HLine struct {
-	Width       string     `xml:"width,attr" json:"width"`               //number or percent value (e.g. "10" or "10%")
-	Gap         float64    `xml:"gap,attr" json:"gap"`                   // greater than 0 then double line
-	BorderColor color.RGBA `xml:"border-color,attr" json:"border-color"` //JSON or XML value: integer gray color (in range from 0 "black" to 255 "white"), default "black"
+	Width           string     `xml:"width,attr" json:"width"`                         //number or percent value (e.g. "10" or "10%")
+	Gap             float64    `xml:"gap,attr" json:"gap"`                             // greater than 0 then double line
+	BorderColor     color.RGBA `xml:"border-color,attr" json:"border-color"`           //JSON or XML value: integer gray color (in range from 0 "black" to 255 "white"), default "black"
+	KeepWithNext    bool       `xml:"keep-with-next,attr" json:"keep-with-next"`       //if true, the line is printed on the same page as the beginning of the next element (default false)
+	PageBreakBefore bool       `xml:"page-break-before,attr" json:"page-break-before"` //if true, the line starts on a new page (default false)
+	PageBreakAfter  bool       `xml:"page-break-after,attr" json:"page-break-after"`   //if true, the next element starts on a new page (default false)
 }
 
 // HTML - a basic HTML elements rendering. It supports
 // only hyperlinks and bold, italic and underscore attributes.
 type HTML struct {
-	Fieldname string `xml:"fieldname,attr" json:"fieldname"` //databind fieldname
-	Value     string `xml:",cdata" json:"html"`              //html text
+	Fieldname       string `xml:"fieldname,attr" json:"fieldname"`                 //databind fieldname
+	Align           string `xml:"align,attr" json:"align"`                         //values: "" (default: "L", or "R" for the "rtl" direction), "L" or "left", "R" or "right", "C" or "center", "J" or "justify", "I" or "inside", "O" or "outside"
+	FontFamily      string `xml:"font-family,attr" json:"font-family"`             //a registered font family (default value: Report.FontFamily)
+	Direction       string `xml:"direction,attr" json:"direction"`                 //values: "" (default: Report.Direction), "ltr" or "rtl". The "rtl" mirrors the default alignment
+	Padding         string `xml:"padding,attr" json:"padding"`                     //padding of all sides (default value: 0, and the bottom padding is 6.4pt)
+	PaddingLeft     string `xml:"padding-left,attr" json:"padding-left"`           //default value: Padding
+	PaddingTop      string `xml:"padding-top,attr" json:"padding-top"`             //default value: Padding
+	PaddingRight    string `xml:"padding-right,attr" json:"padding-right"`         //default value: Padding
+	PaddingBottom   string `xml:"padding-bottom,attr" json:"padding-bottom"`       //default value: Padding
+	LineHeight      string `xml:"line-height,attr" json:"line-height"`             //distance between the lines (default value: Report.LineHeight or font size)
+	KeepWithNext    bool   `xml:"keep-with-next,attr" json:"keep-with-next"`       //if true, the text is printed on the same page as the beginning of the next element (default false)
+	PageBreakBefore bool   `xml:"page-break-before,attr" json:"page-break-before"` //if true, the text starts on a new page (default false)
+	PageBreakAfter  bool   `xml:"page-break-after,attr" json:"page-break-after"`   //if true, the next element starts on a new page (default false)
+	Value           string `xml:",cdata" json:"html"`                              //html text
 }
 
 // Datagrid - Create a table from a data list.
@@ -485,51 +971,141 @@ type Datagrid struct {
 	Width            string     `xml:"width,attr" json:"width"`                         //number or percent value (e.g. "10" or "10%")
 	Merge            bool       `xml:"merge,attr" json:"merge"`                         //if true then all fields will be displayed in a single column (default false)
 	Border           string     `xml:"border,attr" json:"border"`                       //values: "0"(no border, default), "1"(all) or some or all of the following characters: "L"(left), "T"(top), "R"(right),"B"(bottom)
+	Direction        string     `xml:"direction,attr" json:"direction"`                 //values: "" (default: Report.Direction), "ltr" or "rtl". The "rtl" mirrors the default alignment
+	FontFamily       string     `xml:"font-family,attr" json:"font-family"`             //a registered font family (default value: Report.FontFamily)
+	TextDecoration   string     `xml:"text-decoration,attr" json:"text-decoration"`     //the decoration of the data rows, values: "" (default), "underline", "line-through" or "underline line-through"
 	FontSize         float64    `xml:"font-size,attr" json:"font-size"`                 //Default value: Report.FontSize
 	TextColor        color.RGBA `xml:"color,attr" json:"color"`                         //JSON or XML value: in hexadecimal (e.g. #A0522D) or in decimal (e.g 10506797), default "black"
 	BorderColor      color.RGBA `xml:"border-color,attr" json:"border-color"`           //JSON or XML value: integer gray color (in range from 0 "black" to 255 "white"), default "black"
 	BackgroundColor  color.RGBA `xml:"background-color,attr" json:"background-color"`   //JSON or XML value: integer gray color (in range from 0 "black" to 255 "white"), default "black"
 	HeaderBackground color.RGBA `xml:"header-background,attr" json:"header-background"` //JSON or XML value: integer gray color (in range from 0 "black" to 255 "white"), default "black"
 	FooterBackground color.RGBA `xml:"footer-background,attr" json:"footer-background"` //JSON or XML value: integer gray color (in range from 0 "black" to 255 "white"), default "black"
+	Padding          string     `xml:"padding,attr" json:"padding"`                     //cell padding of all sides (default value: Report.Padding)
+	PaddingLeft      string     `xml:"padding-left,attr" json:"padding-left"`           //default value: Padding
+	PaddingTop       string     `xml:"padding-top,attr" json:"padding-top"`             //default value: Padding
+	PaddingRight     string     `xml:"padding-right,attr" json:"padding-right"`         //default value: Padding
+	PaddingBottom    string     `xml:"padding-bottom,attr" json:"padding-bottom"`       //default value: Padding
+	LineHeight       string     `xml:"line-height,attr" json:"line-height"`             //distance between the lines of the cell text (default value: Report.LineHeight)
+	KeepWithNext     bool       `xml:"keep-with-next,attr" json:"keep-with-next"`       //if true, the end of the grid is printed on the same page as the beginning of the next element (default false)
+	MinRows          int        `xml:"min-rows,attr" json:"min-rows"`                   //the minimum number of data rows printed after the grid header on the same page (default 0)
+	PageBreakBefore  bool       `xml:"page-break-before,attr" json:"page-break-before"` //if true, the grid starts on a new page (default false)
+	PageBreakAfter   bool       `xml:"page-break-after,attr" json:"page-break-after"`   //if true, the next element starts on a new page (default false)
 	Columns          []PageItem `xml:"columns" json:"columns"`                          //columns list of the datagrid
 }
 
+// Table - a static table of template rows. The Cell columns of the rows are placed in a common grid
+// of the table columns, and they can span several columns and rows.
+type Table struct {
+	Width           string     `xml:"width,attr" json:"width"`                         //number or percent value (e.g. "10" or "10%"), default "100%"
+	Widths          string     `xml:"widths,attr" json:"widths"`                       //comma separated column widths: numbers or percent values of the table width (e.g. "20%,30,50%"). The missing widths share the remaining width equally.
+	Border          string     `xml:"border,attr" json:"border"`                       //the default border of the cells (default "1")
+	KeepWithNext    bool       `xml:"keep-with-next,attr" json:"keep-with-next"`       //if true, the end of the table is printed on the same page as the beginning of the next element (default false)
+	PageBreakBefore bool       `xml:"page-break-before,attr" json:"page-break-before"` //if true, the table starts on a new page (default false)
+	PageBreakAfter  bool       `xml:"page-break-after,attr" json:"page-break-after"`   //if true, the next element starts on a new page (default false)
+	Rows            []PageItem `xml:"rows" json:"rows"`                                //Row elements with Cell columns
+}
+
+// Group - a vertical group of page elements.
+type Group struct {
+	KeepTogether    bool       `xml:"keep-together,attr" json:"keep-together"`         //if true, the group is moved to the next page if it does not fit in the free space of the page (default false)
+	PageBreakBefore bool       `xml:"page-break-before,attr" json:"page-break-before"` //if true, the group starts on a new page (default false)
+	PageBreakAfter  bool       `xml:"page-break-after,attr" json:"page-break-after"`   //if true, the next element starts on a new page (default false)
+	Elements        []PageItem `xml:"elements" json:"elements"`                        //Row, VGap, HLine, HTML, Datagrid, Table, Group, Labels, NeedSpace
+}
+
+// Section - a part of the details with its own page settings. The section starts on a new page, and
+// the details after the section continue on a new page with the report settings.
+// The unset values are inherited from the report.
+type Section struct {
+	Format            string     `xml:"format,attr" json:"format"`                           //a named format or a custom size, see the format values of New
+	Orientation       string     `xml:"orientation,attr" json:"orientation"`                 //values: "P","portrait","L","landscape"
+	LeftMargin        string     `xml:"left-margin,attr" json:"left-margin"`                 //the margin in points, or "" (default: the report margin). The "0" value is a zero margin.
+	RightMargin       string     `xml:"right-margin,attr" json:"right-margin"`               //default value: the report margin
+	TopMargin         string     `xml:"top-margin,attr" json:"top-margin"`                   //default value: the report margin
+	BottomMargin      string     `xml:"bottom-margin,attr" json:"bottom-margin"`             //default value: the report margin
+	RestartPageNumber bool       `xml:"restart-page-number,attr" json:"restart-page-number"` //if true, the page numbers restart from 1 at the beginning of the section (default false)
+	Header            []PageItem `xml:"header" json:"header"`                                //Row, VGap, HLine. The report header is used if it is not set, an empty list removes the header.
+	Footer            []PageItem `xml:"footer" json:"footer"`                                //Row, VGap, HLine. The report footer is used if it is not set, an empty list removes the footer.
+	Elements          []PageItem `xml:"elements" json:"elements"`                            //Row, VGap, HLine, HTML, Datagrid, Table, Group, Labels, NeedSpace
+}
+
 // Column - Datagrid unit
 type Column struct {
-	Fieldname   string `xml:"fieldname,attr" json:"fieldname"`       //datasource dictonary key (special value: "counter")
-	Label       string `xml:"label,attr" json:"label"`               //Column caption
-	Width       string `xml:"width,attr" json:"width"`               //number or percent value (e.g. "10" or "10%")
-	Align       string `xml:"align,attr" json:"align"`               //values: "L" (default) or "left", "R" or "right", "C" or "center"
-	HeaderAlign string `xml:"header-align,attr" json:"header-align"` //values: "L" (default) or "left", "R" or "right", "C" or "center"
-	FooterAlign string `xml:"footer-align,attr" json:"footer-align"` //values: "L" (default) or "left", "R" or "right", "C" or "center"
-	Footer      string `xml:"footer,attr" json:"footer"`             //static text or databind value
+	Fieldname      string `xml:"fieldname,attr" json:"fieldname"`             //datasource dictonary key (special value: "counter")
+	Label          string `xml:"label,attr" json:"label"`                     //Column caption
+	Width          string `xml:"width,attr" json:"width"`                     //number or percent value (e.g. "10" or "10%")
+	Align          string `xml:"align,attr" json:"align"`                     //values: "" (default: "L", or "R" for the "rtl" direction), "L" or "left", "R" or "right", "C" or "center", "J" or "justify", "I" or "inside", "O" or "outside"
+	VAlign         string `xml:"valign,attr" json:"valign"`                   //values: "" (default: the multiline cells are top and the single line cells are middle aligned), "T" or "top", "M" or "middle", "B" or "bottom"
+	HeaderAlign    string `xml:"header-align,attr" json:"header-align"`       //values: "" (default: "L", or "R" for the "rtl" direction), "L" or "left", "R" or "right", "C" or "center", "J" or "justify", "I" or "inside", "O" or "outside"
+	FooterAlign    string `xml:"footer-align,attr" json:"footer-align"`       //values: "" (default: "L", or "R" for the "rtl" direction), "L" or "left", "R" or "right", "C" or "center", "J" or "justify", "I" or "inside", "O" or "outside"
+	Footer         string `xml:"footer,attr" json:"footer"`                   //static text or databind value
+	FontFamily     string `xml:"font-family,attr" json:"font-family"`         //a registered font family (default value: Datagrid.FontFamily)
+	TextDecoration string `xml:"text-decoration,attr" json:"text-decoration"` //the decoration of the data cells, values: "" (default: Datagrid.TextDecoration), "underline", "line-through" or "underline line-through"
+	Padding        string `xml:"padding,attr" json:"padding"`                 //cell padding of all sides (default value: Datagrid.Padding)
+	PaddingLeft    string `xml:"padding-left,attr" json:"padding-left"`       //default value: Padding
+	PaddingTop     string `xml:"padding-top,attr" json:"padding-top"`         //default value: Padding
+	PaddingRight   string `xml:"padding-right,attr" json:"padding-right"`     //default value: Padding
+	PaddingBottom  string `xml:"padding-bottom,attr" json:"padding-bottom"`   //default value: Padding
+	LineHeight     string `xml:"line-height,attr" json:"line-height"`         //default value: Datagrid.LineHeight
 }
 
 // Report is the principal structure for creating a single PDF document
 type Report struct {
 	Pdf                                                 Generator
 	orientation, format, fontDir, xmlHeader, xmlDetails string
-	//header/footer elements: Row, VGap, HLine. Page elements: Row, VGap, HLine, HTML, Datagrid
+	//header/footer elements: Row, VGap, HLine. Page elements: Row, VGap, HLine, HTML, Datagrid, Table, Group, Labels, Section, NeedSpace
 	header, details, footer []PageItem
+	//the header and footer of the first page and the even pages. The header and footer are used if they are not set.
+	firstHeader, firstFooter, evenHeader, evenFooter []PageItem
 	//Valid datasource types: string or map[string]string (dictonary) or []map[string]string (record list)
-	data                    IM
+	data IM
+	//the current records of the labels data sources, the values are read before the data sources
+	records map[string]SM
+	//registered font families: family -> font style -> registered font style
+	fonts map[string]SM
+	//the first error of the font family selection
+	fontError               error
+	shaper                  Shaper
 	footerHeight, pageBreak float64
-	Title                   string     `xml:"title,attr" json:"title"`
-	Author                  string     `xml:"author,attr" json:"author"`
-	Creator                 string     `xml:"creator,attr" json:"creator"`
-	Subject                 string     `xml:"subject,attr" json:"subject"`
-	Keywords                string     `xml:"keywords,attr" json:"keywords"`
-	LeftMargin              float64    `xml:"left-margin,attr" json:"left-margin"`
-	RightMargin             float64    `xml:"right-margin,attr" json:"right-margin"`
-	TopMargin               float64    `xml:"top-margin,attr" json:"top-margin"`
-	BottomMargin            float64    `xml:"bottom-margin,attr" json:"bottom-margin"`
-	FontFamily              string     `xml:"font-family,attr" json:"font-family"`           //values: "times"(default), "helvetica", "courier" or custom font
-	FontStyle               string     `xml:"font-style,attr" json:"font-style"`             //values: "" (default), "bold", "italic", "bolditalic"
-	FontSize                float64    `xml:"font-size,attr" json:"font-size"`               //Default value: 10
-	TextColor               color.RGBA `xml:"color,attr" json:"color"`                       //JSON or XML value: in hexadecimal (e.g. #A0522D) or in decimal (e.g 10506797), default "black"
-	BorderColor             color.RGBA `xml:"border-color,attr" json:"border-color"`         //JSON or XML value: integer gray color (in range from 0 "black" to 255 "white"), default "black"
-	BackgroundColor         color.RGBA `xml:"background-color,attr" json:"background-color"` //JSON or XML value: integer gray color (in range from 0 "black" to 255 "white"), default "black"
-	ImagePath               string     `xml:"image-path,attr" json:"image-path"`
+	//the y position of the page content below the page header
+	pageTop float64
+	//a details element with the PageBreakAfter value is printed
+	breakAfter bool
+	//the elements of a Box are printed, the page breaks are disabled
+	inBox bool
+	//the label sheet pages are added without the report header and footer
+	labelPage bool
+	//the page margins of the multi-column layout and the index of the current details column
+	pageLeft, pageRight float64
+	column              int
+	//the number of the pages before the restart of the page numbering
+	pageOffset      int
+	Title           string     `xml:"title,attr" json:"title"`
+	Author          string     `xml:"author,attr" json:"author"`
+	Creator         string     `xml:"creator,attr" json:"creator"`
+	Subject         string     `xml:"subject,attr" json:"subject"`
+	Keywords        string     `xml:"keywords,attr" json:"keywords"`
+	LeftMargin      float64    `xml:"left-margin,attr" json:"left-margin"`
+	RightMargin     float64    `xml:"right-margin,attr" json:"right-margin"`
+	TopMargin       float64    `xml:"top-margin,attr" json:"top-margin"`
+	BottomMargin    float64    `xml:"bottom-margin,attr" json:"bottom-margin"`
+	FontFamily      string     `xml:"font-family,attr" json:"font-family"`           //values: "times"(default), "helvetica", "courier" or custom font
+	FontStyle       string     `xml:"font-style,attr" json:"font-style"`             //values: "" (default), "bold", "italic", "bolditalic"
+	FontSize        float64    `xml:"font-size,attr" json:"font-size"`               //Default value: 10
+	TextColor       color.RGBA `xml:"color,attr" json:"color"`                       //JSON or XML value: in hexadecimal (e.g. #A0522D) or in decimal (e.g 10506797), default "black"
+	BorderColor     color.RGBA `xml:"border-color,attr" json:"border-color"`         //JSON or XML value: integer gray color (in range from 0 "black" to 255 "white"), default "black"
+	BackgroundColor color.RGBA `xml:"background-color,attr" json:"background-color"` //JSON or XML value: integer gray color (in range from 0 "black" to 255 "white"), default "black"
+	ImagePath       string     `xml:"image-path,attr" json:"image-path"`
+	Padding         string     `xml:"padding,attr" json:"padding"`               //default cell padding of all sides (default value: 3.2pt)
+	LineHeight      string     `xml:"line-height,attr" json:"line-height"`       //default distance between the lines of the multiline text
+	FallbackFonts   string     `xml:"fallback-fonts,attr" json:"fallback-fonts"` //comma separated list of registered font families for the missing glyphs
+	Direction       string     `xml:"direction,attr" json:"direction"`           //text direction values: "" (default, by the first strong character of the text), "ltr" or "rtl"
+	Columns         int        `xml:"columns,attr" json:"columns"`               //number of the details columns of the pages (default 1). The header and the footer are printed in full width.
+	ColumnGap       float64    `xml:"column-gap,attr" json:"column-gap"`         //distance between the details columns
+	MirrorMargins   bool       `xml:"mirror-margins,attr" json:"mirror-margins"` //if true, the left and right margins are the inner and outer margins of the pages, and they are swapped on the even pages (default false)
+	Gutter          float64    `xml:"gutter,attr" json:"gutter"`                 //extra binding space added to the inner margin
+	Continuous      bool       `xml:"continuous,attr" json:"continuous"`         //if true, the report is a single page without page breaks, and the page height fits the content (default false). The sections are printed with their margins only, the labels without the sheet pages.
+	Unit            string     `xml:"unit,attr" json:"unit"`                     //the unit of the template length values without a unit suffix: "mm" (default), "pt", "cm" or "in"
 }
 
 // SetReportValue - You can set the Report properties safely and type independent.
@@ -552,22 +1128,25 @@ func (rpt *Report) SetReportValue(fieldname string, value interface{}) error {
 			rpt.Keywords = ToString(value, rpt.Keywords)
 		},
 		"LeftMargin": func(value interface{}) {
-			rpt.LeftMargin = ToFloat(value, rpt.LeftMargin) * _mmPt
+			rpt.LeftMargin = getLength(value, rpt.getUnit(), rpt.LeftMargin)
 		},
 		"TopMargin": func(value interface{}) {
-			rpt.TopMargin = ToFloat(value, rpt.TopMargin) * _mmPt
+			rpt.TopMargin = getLength(value, rpt.getUnit(), rpt.TopMargin)
 		},
 		"RightMargin": func(value interface{}) {
-			rpt.RightMargin = ToFloat(value, rpt.RightMargin) * _mmPt
+			rpt.RightMargin = getLength(value, rpt.getUnit(), rpt.RightMargin)
 		},
 		"BottomMargin": func(value interface{}) {
-			rpt.BottomMargin = ToFloat(value, rpt.BottomMargin) * _mmPt
+			rpt.BottomMargin = getLength(value, rpt.getUnit(), rpt.BottomMargin)
+		},
+		"FontFamily": func(value interface{}) {
+			rpt.FontFamily = rpt.getFontFamily(ToString(value, rpt.FontFamily))
 		},
 		"FontStyle": func(value interface{}) {
 			rpt.FontStyle = rpt.parseValue("FontStyle", value).(string)
 		},
 		"FontSize": func(value interface{}) {
-			rpt.FontSize = ToFloat(value, rpt.FontSize)
+			rpt.FontSize = getFontSize(value, rpt.FontSize)
 		},
 		"TextColor": func(value interface{}) {
 			rpt.TextColor = ToRGBA(value, rpt.TextColor)
@@ -581,8 +1160,47 @@ func (rpt *Report) SetReportValue(fieldname string, value interface{}) error {
 		"ImagePath": func(value interface{}) {
 			rpt.ImagePath = ToString(value, rpt.ImagePath)
 		},
+		"Padding": func(value interface{}) {
+			if padding, valid := parseLength(ToString(value, ""), rpt.getUnit()); valid {
+				rpt.Padding = ToString(padding, rpt.Padding)
+			}
+		},
+		"LineHeight": func(value interface{}) {
+			if lineHeight, valid := parseLength(ToString(value, ""), rpt.getUnit()); valid {
+				rpt.LineHeight = ToString(lineHeight, rpt.LineHeight)
+			}
+		},
+		"FallbackFonts": func(value interface{}) {
+			rpt.FallbackFonts = ToString(value, rpt.FallbackFonts)
+		},
+		"Direction": func(value interface{}) {
+			rpt.Direction = rpt.parseValue("Direction", value).(string)
+		},
+		"Columns": func(value interface{}) {
+			rpt.Columns = int(ToInteger(value, int64(rpt.Columns)))
+		},
+		"ColumnGap": func(value interface{}) {
+			rpt.ColumnGap = getLength(value, rpt.getUnit(), rpt.ColumnGap)
+		},
+		"MirrorMargins": func(value interface{}) {
+			rpt.MirrorMargins = ToBoolean(value, rpt.MirrorMargins)
+		},
+		"Gutter": func(value interface{}) {
+			rpt.Gutter = getLength(value, rpt.getUnit(), rpt.Gutter)
+		},
+		"Continuous": func(value interface{}) {
+			rpt.Continuous = ToBoolean(value, rpt.Continuous)
+		},
+		"Unit": func(value interface{}) {
+			if _, found := unitPt[strings.ToLower(ToString(value, ""))]; found {
+				rpt.Unit = strings.ToLower(ToString(value, ""))
+			}
+		},
 	}
 
+	if err := rpt.checkFontFamily(fieldname, value); err != nil {
+		return err
+	}
 	if _, found := vmap[propMap[strings.ToLower(fieldname)]]; found {
 		vmap[propMap[strings.ToLower(fieldname)]](value)
 		return nil
@@ -591,7 +1209,26 @@ func (rpt *Report) SetReportValue(fieldname string, value interface{}) error {
 	return errors.New(err)
 }
 
-func (rpt *Report) createHeaderAndFooter() {
+// getPageSections returns the header and footer elements of a page. The first page is the page number 1
+// of the report or the section with restarted page numbers, the even pages are the even pages of the document.
+func (rpt *Report) getPageSections(pageNo int) (header, footer []PageItem) {
+	var pageHeader, pageFooter []PageItem
+	if pageNo-rpt.pageOffset == 1 {
+		pageHeader, pageFooter = rpt.firstHeader, rpt.firstFooter
+	} else if pageNo%2 == 0 {
+		pageHeader, pageFooter = rpt.evenHeader, rpt.evenFooter
+	}
+	header, footer = rpt.header, rpt.footer
+	if pageHeader != nil {
+		header = pageHeader
+	}
+	if pageFooter != nil {
+		footer = pageFooter
+	}
+	return header, footer
+}
+
+func (rpt *Report) createHeaderAndFooter(header, footer []PageItem) {
 	createSection := func(section string, elements []PageItem) {
 		for index := 0; index < len(elements); index++ {
 			switch elements[index].Item.(type) {
@@ -600,30 +1237,70 @@ func (rpt *Report) createHeaderAndFooter() {
 			}
 		}
 	}
-	createSection("header", rpt.header)
+	createSection("header", header)
 	cx := rpt.Pdf.GetX()
 	cy := rpt.Pdf.GetY()
 	_, pageHeight := rpt.Pdf.GetPageSize()
 	rpt.Pdf.SetY(pageHeight - rpt.BottomMargin - rpt.footerHeight)
-	createSection("footer", rpt.footer)
+	createSection("footer", footer)
 	rpt.Pdf.SetXY(cx, cy)
 }
 
 func (rpt *Report) checkPageBreak(nextHeight float64) bool {
+	if rpt.inBox || rpt.Continuous {
+		return false
+	}
+	return nextHeight > rpt.getPageSpace()
+}
+
+// getPageSpace returns the height of the free space from the current position to the page break
+func (rpt *Report) getPageSpace() float64 {
 	cy := rpt.Pdf.GetY()
 	dLine := rpt.pageBreak
 	if cy < rpt.pageBreak-rpt.footerHeight {
 		dLine -= rpt.footerHeight
 	}
-	if cy+nextHeight > dLine {
-		return true
+	return dLine - cy
+}
+
+// splitCellText splits the text of a multiline cell into the lines fitting in the height and the
+// remaining lines. The fits value is false if the text is not empty and no line fits in the height.
+func (rpt *Report) splitCellText(text string, width, height float64, options IM) (part, rest string, fits bool) {
+	if text == "" {
+		return text, "", true
 	}
-	return false
+	rpt.setPdfFont(ToString(options["fontFamily"], ""), ToString(options["fontStyle"], ""), ToFloat(options["fontSize"], rpt.FontSize))
+	paddingLeft, paddingTop, paddingRight, paddingBottom := rpt.getPadding(options)
+	lines := rpt.wrapTextLines(text, width-paddingLeft-paddingRight)
+	lineHt := rpt.Pdf.GetFontSize()
+	lineH := rpt.getLineHeight(options, lineHt+paddingTop+paddingBottom)
+	count := 0
+	if textHeight := height - paddingTop - paddingBottom - lineHt; textHeight >= 0 {
+		count = int(textHeight/lineH+1e-6) + 1
+	}
+	if count >= len(lines) {
+		return text, "", true
+	}
+	return strings.Join(lines[:count], "\n"), strings.Join(lines[count:], "\n"), count > 0
+}
+
+// splitBorder returns the border of a split cell part, the sides of the split are closed
+func splitBorder(border string, first, last bool) string {
+	if border == "" || border == "0" || border == "1" {
+		return border
+	}
+	if !first && !strings.Contains(border, "T") {
+		border += "T"
+	}
+	if !last && !strings.Contains(border, "B") {
+		border += "B"
+	}
+	return border
 }
 
-func (rpt *Report) getFooterHeight() (fHeight float64) {
-	for index := 0; index < len(rpt.footer); index++ {
-		switch v := rpt.footer[index].Item.(type) {
+func (rpt *Report) getFooterHeight(footer []PageItem) (fHeight float64) {
+	for index := 0; index < len(footer); index++ {
+		switch v := footer[index].Item.(type) {
 		case *Row:
 			fHeight += rpt.createRow("footer", v, true)
 		case *VGap:
@@ -655,10 +1332,13 @@ func (rpt *Report) setHTMLValue(value, fieldname string) string {
 func (rpt *Report) setValue(value string) string {
 	var getValue = func(valueGet string) string {
 		if matched, _ := regexp.MatchString("{{page}}", valueGet); matched {
-			valueGet = strings.ReplaceAll(valueGet, "{{page}}", strconv.Itoa(rpt.Pdf.PageNo()))
+			valueGet = strings.ReplaceAll(valueGet, "{{page}}", strconv.Itoa(rpt.Pdf.PageNo()-rpt.pageOffset))
 		}
 		dbv := strings.Split(valueGet, ".")
 		storeData, isData := rpt.data[dbv[0]]
+		if record, found := rpt.records[dbv[0]]; found {
+			storeData, isData = record, true
+		}
 		if isData {
 			if data, valid := storeData.([]SM); valid {
 				if len(dbv) > 2 {
@@ -704,10 +1384,40 @@ func (rpt *Report) getCellHeight(text string, width float64, options IM) float64
 	if text == "" {
 		text = "X"
 	}
-	rpt.Pdf.SetFont(rpt.FontFamily, options["fontStyle"].(string), options["fontSize"].(float64))
-	lines := rpt.wrapTextLines(text, width-_padding)
+	rpt.setPdfFont(ToString(options["fontFamily"], ""), options["fontStyle"].(string), options["fontSize"].(float64))
+	paddingLeft, paddingTop, paddingRight, paddingBottom := rpt.getPadding(options)
+	lines := rpt.wrapTextLines(text, width-paddingLeft-paddingRight)
 	lineHt := rpt.Pdf.GetFontSize()
-	return float64(len(lines)) * (lineHt + _padding)
+	lineH := rpt.getLineHeight(options, lineHt+paddingTop+paddingBottom)
+	return paddingTop + paddingBottom + lineHt + float64(len(lines)-1)*lineH
+}
+
+// getPadding returns the paddings of an element from the "padding", "paddingLeft", "paddingTop",
+// "paddingRight" and "paddingBottom" options. The default value is the Report.Padding.
+func (rpt *Report) getPadding(options IM) (left, top, right, bottom float64) {
+	padding := ToFloat(ToString(options["padding"], rpt.Padding), _padding/2)
+	return ToFloat(ToString(options["paddingLeft"], ""), padding), ToFloat(ToString(options["paddingTop"], ""), padding),
+		ToFloat(ToString(options["paddingRight"], ""), padding), ToFloat(ToString(options["paddingBottom"], ""), padding)
+}
+
+// hasPadding returns true if any padding value of the element is set
+func hasPadding(options IM) bool {
+	for _, key := range []string{"padding", "paddingLeft", "paddingTop", "paddingRight", "paddingBottom"} {
+		if ToString(options[key], "") != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// getLineHeight returns the distance between the lines of a multiline text from the "lineHeight"
+// option or the Report.LineHeight value. The defValue is used if none of them is set.
+func (rpt *Report) getLineHeight(options IM, defValue float64) float64 {
+	lineHeight := ToFloat(ToString(options["lineHeight"], rpt.LineHeight), 0)
+	if lineHeight <= 0 {
+		return defValue
+	}
+	return lineHeight
 }
 
 func (rpt *Report) createGridHeader(headerOptions IM) {
@@ -721,6 +1431,12 @@ func (rpt *Report) createGridHeader(headerOptions IM) {
 				column["columnWidth"] = (headerOptions["gridWidth"].(float64) - headerOptions["columnsWidth"].(float64)) / float64(len(headerOptions["columns"].([]IM)))
 			}
 			headerOptions["columnWidth"] = column["columnWidth"]
+			headerOptions["fontFamily"] = column["fontFamily"]
+			for _, key := range spacingOptions {
+				if _, found := column[key]; found {
+					headerOptions[key] = column[key]
+				}
+			}
 		} else {
 			headerOptions["text"] = headerOptions["text"].(string) + " " + column["label"].(string)
 		}
@@ -729,24 +1445,56 @@ func (rpt *Report) createGridHeader(headerOptions IM) {
 	}
 }
 
+// getGridRowsHeight returns the height of the first count rows of a datagrid
+func (rpt *Report) getGridRowsHeight(headerOptions IM, rows []SM, count int) (height float64) {
+	for rowIndex := 0; rowIndex < count && rowIndex < len(rows); rowIndex++ {
+		rowHeight := float64(0)
+		for _, column := range headerOptions["columns"].([]IM) {
+			text := rows[rowIndex][column["fieldname"].(string)]
+			if column["fieldname"] == "counter" {
+				text = strconv.Itoa(rowIndex + 1)
+			}
+			if cheight := rpt.getCellHeight(text, column["columnWidth"].(float64), column); cheight > rowHeight {
+				rowHeight = cheight
+			}
+		}
+		height += rowHeight
+	}
+	return height
+}
+
 func (rpt *Report) createDatagrid(gridElement *Datagrid, virtual bool) bool {
-	if len(gridElement.Columns) == 0 {
+	rows, _ := rpt.data[gridElement.Databind].([]SM)
+	return rpt.createGridRows(gridElement, rows, virtual)
+}
+
+// createGridRows prints or measures the datagrid with the rows of the data source
+func (rpt *Report) createGridRows(gridElement *Datagrid, rows []SM, virtual bool) bool {
+	if len(gridElement.Columns) == 0 || len(rows) == 0 {
 		return false
 	}
-	rows, valid := rpt.data[gridElement.Databind].([]SM)
-	if !valid || len(rows) == 0 {
-		return false
+	addToXML := func(section string, values []string) {
+		if !virtual {
+			rpt.addToXML(section, values)
+		}
 	}
 	gridOptions := IM{
 		"xname":           ToString(gridElement.Name, "items"),
 		"border":          ToString(gridElement.Border, "1"),
-		"fontFamily":      rpt.FontFamily,
-		"fontStyle":       rpt.FontStyle,
+		"fontFamily":      rpt.getFontFamily(gridElement.FontFamily),
+		"fontStyle":       rpt.FontStyle + gridElement.TextDecoration,
 		"fontSize":        gridElement.FontSize,
 		"textColor":       gridElement.TextColor,
 		"borderColor":     gridElement.BorderColor,
 		"backgroundColor": gridElement.BackgroundColor,
 		"virtual":         virtual,
+		"padding":         gridElement.Padding,
+		"paddingLeft":     gridElement.PaddingLeft,
+		"paddingTop":      gridElement.PaddingTop,
+		"paddingRight":    gridElement.PaddingRight,
+		"paddingBottom":   gridElement.PaddingBottom,
+		"lineHeight":      gridElement.LineHeight,
+		"direction":       gridElement.Direction,
 	}
 	headerOptions := IM{
 		"fontSize": gridOptions["fontSize"], "textColor": gridOptions["textColor"],
@@ -755,7 +1503,18 @@ func (rpt *Report) createDatagrid(gridElement *Datagrid, virtual bool) bool {
 		"merge":           ToBoolean(gridElement.Merge, false),
 		"fontFamily":      gridOptions["fontFamily"], "fontStyle": "B", "text": "", "height": float64(0),
 		"columns":      make([]IM, 0),
-		"columnsWidth": float64(0), "gridWidth": float64(0), "multiline": false, "virtual": virtual}
+		"columnsWidth": float64(0), "gridWidth": float64(0), "multiline": false, "virtual": virtual,
+		"direction": gridElement.Direction}
+	footerOptions := IM{
+		"fontSize": gridOptions["fontSize"], "textColor": gridOptions["textColor"],
+		"borderColor": gridOptions["borderColor"], "border": gridOptions["border"],
+		"fontFamily": gridOptions["fontFamily"], "fontStyle": "B", "text": "", "height": float64(0),
+		"backgroundColor": ToRGBA(gridElement.FooterBackground, gridElement.BackgroundColor),
+		"multiline":       false, "virtual": virtual, "direction": gridElement.Direction}
+	for _, key := range spacingOptions {
+		headerOptions[key] = gridOptions[key]
+		footerOptions[key] = gridOptions[key]
+	}
 
 	pageWidth, _ := rpt.Pdf.GetPageSize()
 	nwidth := pageWidth - rpt.RightMargin - rpt.LeftMargin
@@ -770,13 +1529,7 @@ func (rpt *Report) createDatagrid(gridElement *Datagrid, virtual bool) bool {
 	}
 	headerOptions["extend"] = (headerOptions["gridWidth"] == nwidth)
 	gridOptions["extend"] = headerOptions["extend"]
-
-	footerOptions := IM{
-		"fontSize": gridOptions["fontSize"], "textColor": gridOptions["textColor"],
-		"borderColor": gridOptions["borderColor"], "border": gridOptions["border"],
-		"fontFamily": gridOptions["fontFamily"], "fontStyle": "B", "text": "", "height": float64(0),
-		"backgroundColor": ToRGBA(gridElement.FooterBackground, gridElement.BackgroundColor),
-		"extend":          headerOptions["extend"], "multiline": false, "virtual": virtual}
+	footerOptions["extend"] = headerOptions["extend"]
 
 	zcol, footers, footerWidth := 0, make([]IM, 0), float64(0)
 	xCol := rpt.LeftMargin
@@ -787,12 +1540,17 @@ func (rpt *Report) createDatagrid(gridElement *Datagrid, virtual bool) bool {
 			return false
 		}
 		columnOptions := IM{
-			"fontFamily": gridOptions["fontFamily"], "fontStyle": gridOptions["fontStyle"],
+			"fontFamily": ToString(column.FontFamily, gridOptions["fontFamily"].(string)), "fontStyle": gridOptions["fontStyle"],
 			"fontSize": gridOptions["fontSize"], "textColor": gridOptions["textColor"],
 			"borderColor": gridOptions["borderColor"], "border": gridOptions["border"],
 			"fieldname": column.Fieldname, "multiline": true,
-			"label":       rpt.setValue(column.Label),
-			"columnWidth": float64(0)}
+			"textDecoration": ToString(column.TextDecoration, gridElement.TextDecoration),
+			"label":          rpt.setValue(column.Label),
+			"columnWidth":    float64(0),
+			"padding":        ToString(column.Padding, gridElement.Padding), "paddingLeft": ToString(column.PaddingLeft, gridElement.PaddingLeft),
+			"paddingTop":   ToString(column.PaddingTop, gridElement.PaddingTop),
+			"paddingRight": ToString(column.PaddingRight, gridElement.PaddingRight), "paddingBottom": ToString(column.PaddingBottom, gridElement.PaddingBottom),
+			"lineHeight": ToString(column.LineHeight, gridElement.LineHeight)}
 		if !headerOptions["merge"].(bool) {
 			columnWidth := ToString(column.Width, "")
 			if columnWidth != "" {
@@ -805,7 +1563,8 @@ func (rpt *Report) createDatagrid(gridElement *Datagrid, virtual bool) bool {
 				if len(gridElement.Columns)-1 == index {
 					columnOptions["columnWidth"] = lnWidth
 				} else {
-					columnOptions["columnWidth"] = rpt.Pdf.GetTextWidth(columnOptions["label"].(string)) + _padding
+					paddingLeft, _, paddingRight, _ := rpt.getPadding(columnOptions)
+					columnOptions["columnWidth"] = rpt.Pdf.GetTextWidth(columnOptions["label"].(string)) + paddingLeft + paddingRight
 				}
 				zcol++
 			}
@@ -821,11 +1580,12 @@ func (rpt *Report) createDatagrid(gridElement *Datagrid, virtual bool) bool {
 				headerOptions["height"] = cheight
 			}
 		}
-		columnOptions["headerAlign"] = ToString(column.HeaderAlign, "L")
-		columnOptions["align"] = ToString(column.Align, "L")
+		columnOptions["headerAlign"] = column.HeaderAlign
+		columnOptions["align"] = column.Align
+		columnOptions["valign"] = ToString(column.VAlign, "")
 
 		footerValue := rpt.setValue(ToString(column.Footer, ""))
-		footerAlign := ToString(column.FooterAlign, "L")
+		footerAlign := column.FooterAlign
 		if footerValue != "" {
 			if len(footers) == 0 {
 				footers = append(footers, IM{
@@ -848,12 +1608,19 @@ func (rpt *Report) createDatagrid(gridElement *Datagrid, virtual bool) bool {
 		headerOptions["columns"] = append(headerOptions["columns"].([]IM), columnOptions)
 	}
 	if !headerOptions["merge"].(bool) {
+		if !virtual && gridElement.MinRows > 0 {
+			leftMargin := rpt.LeftMargin
+			rpt.keepTogether(headerOptions["height"].(float64) + rpt.getGridRowsHeight(headerOptions, rows, gridElement.MinRows))
+			shiftGridColumns(headerOptions, rpt.LeftMargin-leftMargin)
+		}
 		rpt.createGridHeader(headerOptions)
 	}
 
+	border := gridOptions["border"]
 	for rowIndex := 0; rowIndex < len(rows); rowIndex++ {
 		row := rows[rowIndex]
-		rpt.addToXML("details", []string{gridOptions["xname"].(string)})
+		rpt.Pdf.SetX(rpt.LeftMargin)
+		addToXML("details", []string{gridOptions["xname"].(string)})
 		gridOptions["height"] = float64(0)
 		gridOptions["text"] = ""
 		for colIndex := 0; colIndex < len(headerOptions["columns"].([]IM)); colIndex++ {
@@ -868,17 +1635,21 @@ func (rpt *Report) createDatagrid(gridElement *Datagrid, virtual bool) bool {
 				}
 			}
 			if !headerOptions["merge"].(bool) {
-				cheight := rpt.getCellHeight(column["text"].(string), column["columnWidth"].(float64), gridOptions)
+				cheight := rpt.getCellHeight(column["text"].(string), column["columnWidth"].(float64), column)
 				if cheight > gridOptions["height"].(float64) {
 					gridOptions["height"] = cheight
 				}
 			} else {
 				gridOptions["text"] = gridOptions["text"].(string) + " " + column["text"].(string)
-				rpt.addToXML("details", []string{column["fieldname"].(string), column["text"].(string), column["fieldname"].(string)})
+				addToXML("details", []string{column["fieldname"].(string), column["text"].(string), column["fieldname"].(string)})
 			}
 		}
-		if rpt.checkPageBreak(gridOptions["height"].(float64)) {
-			rpt.addPage()
+		if !headerOptions["merge"].(bool) && !virtual {
+			rpt.splitGridRow(headerOptions, gridOptions)
+		} else if !virtual && rpt.checkPageBreak(gridOptions["height"].(float64)) {
+			leftMargin := rpt.LeftMargin
+			rpt.nextPage()
+			shiftGridColumns(headerOptions, rpt.LeftMargin-leftMargin)
 			if !headerOptions["merge"].(bool) {
 				rpt.createGridHeader(headerOptions)
 			}
@@ -886,15 +1657,23 @@ func (rpt *Report) createDatagrid(gridElement *Datagrid, virtual bool) bool {
 		if !headerOptions["merge"].(bool) {
 			for colIndex := 0; colIndex < len(headerOptions["columns"].([]IM)); colIndex++ {
 				column := headerOptions["columns"].([]IM)[colIndex]
-				gridOptions["text"] = column["text"]
+				gridOptions["text"] = gridCellText(column)
 				gridOptions["columnWidth"] = column["columnWidth"]
 				gridOptions["xCol"] = column["xCol"]
 				gridOptions["align"] = column["align"]
+				gridOptions["valign"] = column["valign"]
+				gridOptions["fontFamily"] = column["fontFamily"]
+				gridOptions["fontStyle"] = rpt.FontStyle + column["textDecoration"].(string)
+				for _, key := range spacingOptions {
+					gridOptions[key] = column[key]
+				}
 				gridOptions["ln"] = column["ln"]
 				gridOptions["multiline"] = column["multiline"]
 				rpt.createCell(gridOptions)
-				rpt.addToXML("details", []string{column["fieldname"].(string), column["text"].(string), column["fieldname"].(string)})
+				addToXML("details", []string{column["fieldname"].(string), column["text"].(string), column["fieldname"].(string)})
+				delete(column, "rest")
 			}
+			gridOptions["border"] = border
 		} else {
 			gridOptions["text"] = strings.Trim(gridOptions["text"].(string), " ")
 			gridOptions["columnWidth"] = float64(0)
@@ -903,9 +1682,10 @@ func (rpt *Report) createDatagrid(gridElement *Datagrid, virtual bool) bool {
 			gridOptions["height"] = float64(0)
 			rpt.createCell(gridOptions)
 		}
-		rpt.addToXML("details", []string{gridOptions["xname"].(string)})
+		addToXML("details", []string{gridOptions["xname"].(string)})
 	}
 	if !headerOptions["merge"].(bool) {
+		rpt.Pdf.SetX(rpt.LeftMargin)
 		for colIndex := 0; colIndex < len(footers); colIndex++ {
 			column := footers[colIndex]
 			cheight := rpt.getCellHeight(column["text"].(string), column["columnWidth"].(float64), footerOptions)
@@ -924,31 +1704,113 @@ func (rpt *Report) createDatagrid(gridElement *Datagrid, virtual bool) bool {
 				footerOptions["ln"] = 0
 			}
 			rpt.createCell(footerOptions)
-			rpt.addToXML("footer", []string{gridOptions["xname"].(string), column["text"].(string), gridOptions["xname"].(string)})
+			addToXML("footer", []string{gridOptions["xname"].(string), column["text"].(string), gridOptions["xname"].(string)})
 		}
 	}
 
 	return true
 }
 
-func (rpt *Report) createCell(options IM) float64 {
-	//x, y, width, height, text, border, ln, align, padding, multiline, extend
-	rpt.setPageStyle(options)
-	virtual := ToBoolean(options["virtual"], false)
-	text := ToString(options["text"], "")
-	multiline := ToBoolean(options["multiline"], false)
-	ln := ToBoolean(options["ln"], false)
-	padding := _padding
-	border := ToString(options["border"], "")
-	align := ToString(options["align"], "L")
-	fill := false
-	backgroundColor := ToRGBA(options["backgroundColor"], rpt.BackgroundColor)
-	if backgroundColor != rpt.BackgroundColor {
-		fill = true
+// gridCellText returns the text of a datagrid row cell, or the remaining text of a split cell
+func gridCellText(column IM) string {
+	if rest, found := column["rest"]; found {
+		return rest.(string)
 	}
-	if !fill && border == "" && !multiline {
-		padding = 0
+	return column["text"].(string)
+}
+
+// shiftGridColumns shifts the x positions of the datagrid columns by the left margin change of a new page
+func shiftGridColumns(headerOptions IM, shift float64) {
+	for _, column := range headerOptions["columns"].([]IM) {
+		column["xCol"] = column["xCol"].(float64) + shift
+	}
+}
+
+// splitGridRow prints the lines of the datagrid row cells fitting in the free space of the page, and
+// adds the next page with the grid header while the rest of the row does not fit in the page. The row
+// is moved to the next page if any cell has no line fitting in the free space. The remaining texts of the split
+// cells are stored in the "rest" column options, and the "height" and "border" grid options are set
+// to the values of the last part of the row.
+func (rpt *Report) splitGridRow(headerOptions, gridOptions IM) {
+	columns := headerOptions["columns"].([]IM)
+	border := gridOptions["border"].(string)
+	first, newPage := true, rpt.Pdf.GetY() == rpt.pageTop
+	for rpt.checkPageBreak(gridOptions["height"].(float64)) {
+		parts, rests := make([]string, len(columns)), make([]string, len(columns))
+		fits, rest := true, false
+		for colIndex, column := range columns {
+			partText, restText, textFits := rpt.splitCellText(
+				gridCellText(column), column["columnWidth"].(float64), rpt.getPageSpace(), column)
+			parts[colIndex], rests[colIndex] = partText, restText
+			fits, rest = fits && textFits, rest || restText != ""
+		}
+		if fits && rest {
+			height := float64(0)
+			for colIndex, column := range columns {
+				if cheight := rpt.getCellHeight(parts[colIndex], column["columnWidth"].(float64), column); cheight > height {
+					height = cheight
+				}
+			}
+			for colIndex, column := range columns {
+				gridOptions["text"] = parts[colIndex]
+				gridOptions["columnWidth"] = column["columnWidth"]
+				gridOptions["xCol"] = column["xCol"]
+				gridOptions["align"] = column["align"]
+				gridOptions["valign"] = column["valign"]
+				gridOptions["fontFamily"] = column["fontFamily"]
+				gridOptions["fontStyle"] = rpt.FontStyle + column["textDecoration"].(string)
+				for _, key := range spacingOptions {
+					gridOptions[key] = column[key]
+				}
+				gridOptions["ln"] = column["ln"]
+				gridOptions["multiline"] = column["multiline"]
+				gridOptions["height"] = height
+				gridOptions["border"] = splitBorder(border, first, false)
+				rpt.createCell(gridOptions)
+				column["rest"] = rests[colIndex]
+			}
+			gridOptions["border"] = splitBorder(border, false, true)
+			first = false
+		} else if newPage {
+			// the row does not fit in an empty page
+			return
+		}
+		leftMargin := rpt.LeftMargin
+		rpt.nextPage()
+		// the next details column or the mirrored margins of the new page move the grid columns
+		shiftGridColumns(headerOptions, rpt.LeftMargin-leftMargin)
+		rpt.createGridHeader(headerOptions)
+		newPage = true
+		gridOptions["height"] = float64(0)
+		for _, column := range columns {
+			if cheight := rpt.getCellHeight(gridCellText(column), column["columnWidth"].(float64), column); cheight > gridOptions["height"].(float64) {
+				gridOptions["height"] = cheight
+			}
+		}
+	}
+}
+
+func (rpt *Report) createCell(options IM) float64 {
+	//x, y, width, height, text, border, ln, align, padding, multiline, extend
+	rpt.setPageStyle(options)
+	virtual := ToBoolean(options["virtual"], false)
+	text := ToString(options["text"], "")
+	multiline := ToBoolean(options["multiline"], false)
+	ln := ToBoolean(options["ln"], false)
+	paddingLeft, paddingTop, paddingRight, paddingBottom := rpt.getPadding(options)
+	border := ToString(options["border"], "")
+	align := ToString(options["align"], "")
+	valign := ToString(options["valign"], "")
+	direction := ToString(options["direction"], rpt.Direction)
+	fill := false
+	backgroundColor := ToRGBA(options["backgroundColor"], rpt.BackgroundColor)
+	if backgroundColor != rpt.BackgroundColor {
+		fill = true
+	}
+	if !fill && border == "" && !multiline && !hasPadding(options) {
+		paddingLeft, paddingTop, paddingRight, paddingBottom = 0, 0, 0, 0
 	}
+	padding := paddingLeft + paddingRight
 
 	pageWidth, _ := rpt.Pdf.GetPageSize()
 	lineHt := rpt.Pdf.GetFontSize()
@@ -988,29 +1850,50 @@ func (rpt *Report) createCell(options IM) float64 {
 		if height == 0 {
 			height = rpt.getCellHeight(text, width, options)
 		}
+		options["cellWidth"] = width
 		if !virtual {
 			//w, h, lineH, padding float64, txtStr, borderStr, alignStr string, fill bool
 			rpt.Pdf.MultiCell(IM{
-				"w": width, "h": height, "lineH": lineHt + padding, "padding": padding,
-				"txtStr": text, "borderStr": border, "alignStr": align, "fill": fill,
+				"w": width, "h": height, "lineH": rpt.getLineHeight(options, lineHt+paddingTop+paddingBottom),
+				"paddingLeft": paddingLeft, "paddingTop": paddingTop, "paddingRight": paddingRight, "paddingBottom": paddingBottom,
+				"txtStr": text, "borderStr": border, "alignStr": rpt.pageAlign(defaultAlign(align, direction)), "fill": fill,
+				"valignStr": valign, "baseline": options["baseline"], "directionStr": direction,
 			})
 		}
 		if ln {
 			rpt.Pdf.SetY(startY + height)
 		} else {
-			rpt.Pdf.SetY(startY)
+			rpt.Pdf.SetXY(xCol+width, startY)
 		}
 		return height
 	}
 
-	if lineHt+padding > height {
-		height = lineHt + padding
+	if align == "J" {
+		// a single line is always the last line of its paragraph
+		align = lineStart(textDirection(text, direction))
+	}
+	align = rpt.pageAlign(defaultAlign(align, direction))
+	if overflow := ToString(options["overflow"], ""); overflow != "" && !virtual {
+		textWidth := width
+		if textWidth == 0 {
+			textWidth = pageWidth - rpt.RightMargin - xCol
+		}
+		text = rpt.fitText(text, textWidth-padding, overflow, options)
+	}
+	if lineHt+paddingTop+paddingBottom > height {
+		height = lineHt + paddingTop + paddingBottom
 	}
 	if !virtual {
 		rpt.Pdf.Cell(IM{
-			"w": width, "h": height, "padding": padding, "txtStr": text, "borderStr": border,
-			"alignStr": align, "fill": fill, "ln": ln,
+			"w": width, "h": height, "txtStr": text, "borderStr": border,
+			"paddingLeft": paddingLeft, "paddingTop": paddingTop, "paddingRight": paddingRight, "paddingBottom": paddingBottom,
+			"alignStr": align, "fill": fill, "ln": ln, "valignStr": ToString(valign, "M"), "baseline": options["baseline"],
+			"directionStr": direction,
 		})
+	} else if !ln {
+		rpt.Pdf.SetX(xCol + width)
+	} else {
+		rpt.Pdf.SetXY(rpt.LeftMargin, startY+height)
 	}
 	if rpt.Pdf.GetY()-startY > height {
 		return rpt.Pdf.GetY() - startY
@@ -1067,7 +1950,7 @@ func (rpt *Report) drawImage(v *Image) {
 		v.Width = v.Height * (v.MaxWidth / v.MaxHeight)
 	}
 	if rpt.checkPageBreak(v.Height) {
-		rpt.addPage()
+		rpt.nextPage()
 	}
 	rpt.Pdf.AddImage(v, rpt.Pdf.GetX(), rpt.Pdf.GetY(), IM{"ImagePath": rpt.ImagePath})
 }
@@ -1089,7 +1972,8 @@ func (rpt *Report) createImage(v *Image, rowHeight float64, virtual bool) (float
 			}
 		}
 		if height <= 0 && rowHeight > 0 {
-			height = rowHeight - _padding/3
+			paddingLeft, _, paddingRight, _ := rpt.getPadding(IM{})
+			height = rowHeight - (paddingLeft+paddingRight)/3
 		}
 		v.Height = height
 		if !virtual {
@@ -1102,13 +1986,422 @@ func (rpt *Report) createImage(v *Image, rowHeight float64, virtual bool) (float
 	return rowHeight, v.Width
 }
 
+// createBox prints or measures a Box. The elements are printed between the box margins, the height
+// of the background and the borders is at least the rowHeight. It returns the height and the width of the box.
+func (rpt *Report) createBox(v *Box, rowHeight float64, virtual bool) (float64, float64) {
+	pageWidth, _ := rpt.Pdf.GetPageSize()
+	startX, startY := rpt.Pdf.GetX(), rpt.Pdf.GetY()
+	width := float64(0)
+	if strings.HasSuffix(v.Width, "%") {
+		width = (pageWidth - rpt.LeftMargin - rpt.RightMargin) * ToFloat(strings.Replace(v.Width, "%", "", -1), 0) / 100
+	} else {
+		width = ToFloat(v.Width, 0)
+	}
+	if width <= 0 || startX+width > pageWidth-rpt.RightMargin {
+		width = pageWidth - rpt.RightMargin - startX
+	}
+	paddingLeft, paddingTop, paddingRight, paddingBottom := rpt.getPadding(IM{
+		"padding": ToString(v.Padding, "0"), "paddingLeft": v.PaddingLeft, "paddingTop": v.PaddingTop,
+		"paddingRight": v.PaddingRight, "paddingBottom": v.PaddingBottom})
+
+	leftMargin, rightMargin, inBox := rpt.LeftMargin, rpt.RightMargin, rpt.inBox
+	rpt.LeftMargin, rpt.RightMargin, rpt.inBox = startX+paddingLeft, pageWidth-startX-width+paddingRight, true
+	createElements := func(virtual bool) float64 {
+		rpt.Pdf.SetY(startY + paddingTop)
+		rpt.createBoxElements(v.Elements, virtual)
+		return rpt.Pdf.GetY() - startY + paddingBottom
+	}
+	height := createElements(true)
+	if !virtual {
+		if rowHeight > height {
+			height = rowHeight
+		}
+		rpt.setPageStyle(IM{"borderColor": v.BorderColor, "backgroundColor": v.BackgroundColor})
+		rpt.Pdf.SetXY(startX, startY)
+		rpt.Pdf.Cell(IM{
+			"w": width, "h": height, "txtStr": "", "borderStr": v.Border, "alignStr": "L",
+			"fill": v.BackgroundColor != rpt.BackgroundColor, "ln": false})
+		createElements(false)
+	}
+	rpt.LeftMargin, rpt.RightMargin, rpt.inBox = leftMargin, rightMargin, inBox
+	rpt.Pdf.SetXY(startX, startY)
+	return height, width
+}
+
+// createBoxElements prints or measures the elements of a Box or a label within the current margins
+func (rpt *Report) createBoxElements(elements []PageItem, virtual bool) {
+	for index := 0; index < len(elements); index++ {
+		rpt.Pdf.SetX(rpt.LeftMargin)
+		switch element := elements[index].Item.(type) {
+		case *Row:
+			if rpt.rowVisible(element) {
+				rpt.createRow("details", element, virtual)
+			}
+		case *VGap:
+			rpt.Pdf.SetY(rpt.Pdf.GetY() + element.Height)
+		case *HLine:
+			rpt.createLine(element, virtual)
+		case *HTML:
+			rpt.createHTML(element, virtual)
+		case *Datagrid:
+			rpt.createDatagrid(element, virtual)
+		case *Table:
+			rpt.createTable(element, virtual)
+		}
+	}
+}
+
+// getLabelsLayout returns the sheet values of the labels: columns, rows, label width, label height,
+// left, top, horizontal gap and vertical gap. The unset values are taken from the Sheet preset.
+func getLabelsLayout(v *Labels) [8]float64 {
+	layout := [8]float64{float64(v.Columns), float64(v.Rows), v.Width, v.Height, v.Left, v.Top, v.HGap, v.VGap}
+	if preset, found := labelSheets[strings.ToLower(v.Sheet)]; found {
+		for index := range layout {
+			if layout[index] == 0 {
+				layout[index] = preset[index]
+				if index > 1 {
+					layout[index] *= _mmPt
+				}
+			}
+		}
+	}
+	return layout
+}
+
+// createLabels prints a label for every record of the data source. The labels start on a new page without
+// the report header and footer unless the current page is empty and has no header and footer, and the
+// first Offset positions of the first sheet are skipped. In a continuous report the label rows are printed
+// from the current position without the sheet pages.
+func (rpt *Report) createLabels(v *Labels) {
+	records, valid := rpt.data[v.Databind].([]SM)
+	layout := getLabelsLayout(v)
+	cols, rows := int(layout[0]), int(layout[1])
+	width, height, left, top, hgap, vgap := layout[2], layout[3], layout[4], layout[5], layout[6], layout[7]
+	if !valid || len(records) == 0 || cols < 1 || rows < 1 || width <= 0 || height <= 0 {
+		return
+	}
+	labelPage := rpt.labelPage
+	rpt.labelPage = true
+	if rpt.records == nil {
+		rpt.records = make(map[string]SM)
+	}
+	record, bound := rpt.records[v.Databind]
+	defer func() {
+		rpt.labelPage = labelPage
+		if bound {
+			rpt.records[v.Databind] = record
+		} else {
+			delete(rpt.records, v.Databind)
+		}
+	}()
+	if (rpt.Pdf.GetY() > rpt.TopMargin || rpt.footerHeight > 0) && !rpt.Continuous {
+		rpt.addPage()
+	}
+	position := 0
+	if v.Offset > 0 {
+		position = v.Offset % (cols * rows)
+	}
+	// the continuous label rows are not broken into sheets
+	if rpt.Continuous {
+		top, rows = rpt.Pdf.GetY(), (position+len(records))/cols+1
+	}
+	paddingLeft, paddingTop, paddingRight, _ := rpt.getPadding(IM{"padding": ToString(v.Padding, "0")})
+	pageWidth, _ := rpt.Pdf.GetPageSize()
+	leftMargin, rightMargin, inBox := rpt.LeftMargin, rpt.RightMargin, rpt.inBox
+	for index := 0; index < len(records); index++ {
+		if position == cols*rows {
+			rpt.LeftMargin, rpt.RightMargin, rpt.inBox = leftMargin, rightMargin, inBox
+			rpt.addPage()
+			leftMargin, rightMargin = rpt.LeftMargin, rpt.RightMargin
+			position = 0
+		}
+		x := left + float64(position%cols)*(width+hgap)
+		y := top + float64(position/cols)*(height+vgap)
+		rpt.LeftMargin, rpt.RightMargin, rpt.inBox = x+paddingLeft, pageWidth-x-width+paddingRight, true
+		if v.Border != "" && v.Border != "0" {
+			rpt.setPageStyle(IM{"borderColor": v.BorderColor})
+			rpt.Pdf.SetXY(x, y)
+			rpt.Pdf.Cell(IM{
+				"w": width, "h": height, "txtStr": "", "borderStr": v.Border, "alignStr": "L", "fill": false, "ln": false})
+		}
+		rpt.records[v.Databind] = records[index]
+		rpt.Pdf.SetXY(rpt.LeftMargin, y+paddingTop)
+		rpt.createBoxElements(v.Elements, false)
+		position++
+	}
+	rpt.LeftMargin, rpt.RightMargin, rpt.inBox = leftMargin, rightMargin, inBox
+	if rpt.Continuous {
+		rpt.Pdf.SetXY(rpt.LeftMargin, top+rpt.getLabelsHeight(v))
+		return
+	}
+	rpt.Pdf.SetXY(rpt.LeftMargin, rpt.pageTop)
+	rpt.breakAfter = true
+}
+
+// getLabelsHeight returns the height of the label rows in a continuous report
+func (rpt *Report) getLabelsHeight(v *Labels) float64 {
+	records, valid := rpt.data[v.Databind].([]SM)
+	layout := getLabelsLayout(v)
+	cols, rows, height, vgap := int(layout[0]), int(layout[1]), layout[3], layout[7]
+	if !valid || len(records) == 0 || cols < 1 || rows < 1 || layout[2] <= 0 || height <= 0 {
+		return 0
+	}
+	count := len(records)
+	if v.Offset > 0 {
+		count += v.Offset % (cols * rows)
+	}
+	lines := (count + cols - 1) / cols
+	return float64(lines)*(height+vgap) - vgap
+}
+
+// createSection prints the elements of a Section with the page settings of the section,
+// and restores the report settings for the next elements
+func (rpt *Report) createSection(v *Section) {
+	// a continuous report has a single page: the elements are printed with the section margins,
+	// the page settings and the header and footer of the section are not used
+	if rpt.Continuous {
+		leftMargin, rightMargin := rpt.setSectionMargins(v)
+		rpt.Pdf.SetX(rpt.LeftMargin)
+		rpt.createElements("details", v.Elements)
+		rpt.LeftMargin, rpt.RightMargin = leftMargin, rightMargin
+		rpt.Pdf.SetX(rpt.LeftMargin)
+		return
+	}
+	format, orientation, header, footer := rpt.format, rpt.orientation, rpt.header, rpt.footer
+	firstHeader, firstFooter, evenHeader, evenFooter := rpt.firstHeader, rpt.firstFooter, rpt.evenHeader, rpt.evenFooter
+	leftMargin, rightMargin, topMargin, bottomMargin := rpt.pageLeft, rpt.pageRight, rpt.TopMargin, rpt.BottomMargin
+	if v.Format != "" {
+		rpt.format = v.Format
+	}
+	if v.Orientation != "" {
+		rpt.orientation = v.Orientation
+	}
+	// the section header and footer replace all header and footer variants of the report
+	if v.Header != nil {
+		rpt.header, rpt.firstHeader, rpt.evenHeader = v.Header, nil, nil
+	}
+	if v.Footer != nil {
+		rpt.footer, rpt.firstFooter, rpt.evenFooter = v.Footer, nil, nil
+	}
+	rpt.pageLeft, rpt.pageRight = ToFloat(v.LeftMargin, leftMargin), ToFloat(v.RightMargin, rightMargin)
+	rpt.TopMargin, rpt.BottomMargin = ToFloat(v.TopMargin, topMargin), ToFloat(v.BottomMargin, bottomMargin)
+	setPage := func() {
+		rpt.LeftMargin, rpt.RightMargin = rpt.pageLeft, rpt.pageRight
+		rpt.Pdf.SetPageSize(rpt.format, rpt.orientation)
+		rpt.footerHeight = rpt.getFooterHeight(rpt.footer)
+	}
+
+	setPage()
+	if v.RestartPageNumber {
+		rpt.pageOffset = rpt.Pdf.PageNo()
+	}
+	rpt.breakAfter = false
+	rpt.addPage()
+	rpt.createElements("details", v.Elements)
+
+	rpt.format, rpt.orientation, rpt.header, rpt.footer = format, orientation, header, footer
+	rpt.firstHeader, rpt.firstFooter, rpt.evenHeader, rpt.evenFooter = firstHeader, firstFooter, evenHeader, evenFooter
+	rpt.pageLeft, rpt.pageRight, rpt.TopMargin, rpt.BottomMargin = leftMargin, rightMargin, topMargin, bottomMargin
+	setPage()
+	rpt.breakAfter = true
+}
+
+// setSectionMargins sets the left and right margins of a section in a continuous report,
+// and returns the previous margins
+func (rpt *Report) setSectionMargins(v *Section) (leftMargin, rightMargin float64) {
+	leftMargin, rightMargin = rpt.LeftMargin, rpt.RightMargin
+	rpt.LeftMargin, rpt.RightMargin = ToFloat(v.LeftMargin, leftMargin), ToFloat(v.RightMargin, rightMargin)
+	return leftMargin, rightMargin
+}
+
+// tableCell - a Table cell placed in the grid of the table columns and rows
+type tableCell struct {
+	cell             *Cell
+	row, col         int
+	rowspan, colspan int
+}
+
+// getTableCells returns the cells of the table rows placed in the grid of the columns, and the
+// number of the columns. A cell is placed in the first column not covered by the cells of the previous rows.
+func getTableCells(rows []PageItem) (cells []tableCell, cols int) {
+	covered := make(map[[2]int]bool)
+	for rowIndex := 0; rowIndex < len(rows); rowIndex++ {
+		row, valid := rows[rowIndex].Item.(*Row)
+		if !valid {
+			continue
+		}
+		col := 0
+		for index := 0; index < len(row.Columns); index++ {
+			cell, valid := row.Columns[index].Item.(*Cell)
+			if !valid {
+				continue
+			}
+			for covered[[2]int{rowIndex, col}] {
+				col++
+			}
+			tc := tableCell{cell: cell, row: rowIndex, col: col, rowspan: cell.Rowspan, colspan: cell.Colspan}
+			if tc.rowspan < 1 {
+				tc.rowspan = 1
+			}
+			if tc.rowspan > len(rows)-rowIndex {
+				tc.rowspan = len(rows) - rowIndex
+			}
+			if tc.colspan < 1 {
+				tc.colspan = 1
+			}
+			for r := rowIndex; r < rowIndex+tc.rowspan; r++ {
+				for c := col; c < col+tc.colspan; c++ {
+					covered[[2]int{r, c}] = true
+				}
+			}
+			cells = append(cells, tc)
+			col += tc.colspan
+			if col > cols {
+				cols = col
+			}
+		}
+	}
+	return cells, cols
+}
+
+// getTableWidths returns the widths of the table columns
+func (rpt *Report) getTableWidths(v *Table, cols int) []float64 {
+	pageWidth, _ := rpt.Pdf.GetPageSize()
+	nwidth := pageWidth - rpt.LeftMargin - rpt.RightMargin
+	tableWidth := nwidth
+	if strings.HasSuffix(v.Width, "%") {
+		tableWidth = nwidth * ToFloat(strings.Replace(v.Width, "%", "", -1), 100) / 100
+	} else if width := ToFloat(v.Width, 0); width > 0 && width < nwidth {
+		tableWidth = width
+	}
+	values := strings.Split(v.Widths, ",")
+	widths := make([]float64, cols)
+	fixed, free := float64(0), 0
+	for index := range widths {
+		value := ""
+		if index < len(values) {
+			value = strings.TrimSpace(values[index])
+		}
+		if strings.HasSuffix(value, "%") {
+			widths[index] = tableWidth * ToFloat(strings.Replace(value, "%", "", -1), 0) / 100
+		} else {
+			widths[index] = ToFloat(value, 0)
+		}
+		if widths[index] > 0 {
+			fixed += widths[index]
+		} else {
+			free++
+		}
+	}
+	for index := range widths {
+		if widths[index] <= 0 && tableWidth > fixed {
+			widths[index] = (tableWidth - fixed) / float64(free)
+		}
+	}
+	return widths
+}
+
+// createTable prints or measures a Table. The row heights are measured from the cells of the row,
+// and the spanned cells enlarge the last row they span. The rows joined by spanned cells are printed
+// on the same page.
+func (rpt *Report) createTable(v *Table, virtual bool) {
+	cells, cols := getTableCells(v.Rows)
+	if cols == 0 {
+		return
+	}
+	widths := rpt.getTableWidths(v, cols)
+	colX := make([]float64, cols+1)
+	colX[0] = rpt.LeftMargin
+	for index, width := range widths {
+		colX[index+1] = colX[index] + width
+	}
+	heights := make([]float64, len(v.Rows))
+	for index := 0; index < len(v.Rows); index++ {
+		if row, valid := v.Rows[index].Item.(*Row); valid {
+			heights[index] = row.Height
+		}
+	}
+	spanHeight := func(row, rowspan int) (height float64) {
+		for index := row; index < row+rowspan; index++ {
+			height += heights[index]
+		}
+		return height
+	}
+
+	cx, cy := rpt.Pdf.GetX(), rpt.Pdf.GetY()
+	options := make([]IM, len(cells))
+	for index, tc := range cells {
+		options[index] = rpt.cellOptions(tc.cell, true)
+		options[index]["border"] = ToString(tc.cell.Border, v.Border)
+		options[index]["multiline"] = tc.cell.Multiline
+		options[index]["valign"] = tc.cell.VAlign
+		options[index]["columnWidth"] = colX[tc.col+tc.colspan] - colX[tc.col]
+		options[index]["xCol"] = colX[tc.col]
+		options[index]["ln"] = false
+	}
+	// the single row cells are measured before the spanned cells
+	for _, spanned := range []bool{false, true} {
+		for index, tc := range cells {
+			if (tc.rowspan > 1) != spanned {
+				continue
+			}
+			options[index]["height"] = float64(0)
+			rpt.Pdf.SetXY(colX[tc.col], cy)
+			if height, rowsHeight := rpt.createCell(options[index]), spanHeight(tc.row, tc.rowspan); height > rowsHeight {
+				heights[tc.row+tc.rowspan-1] += height - rowsHeight
+			}
+		}
+	}
+
+	rpt.Pdf.SetXY(cx, cy)
+	y := cy
+	for row := 0; row < len(v.Rows); {
+		end := row + 1
+		for extended := true; extended; {
+			extended = false
+			for _, tc := range cells {
+				if tc.row >= row && tc.row < end && tc.row+tc.rowspan > end {
+					end, extended = tc.row+tc.rowspan, true
+				}
+			}
+		}
+		groupHeight := spanHeight(row, end-row)
+		if !virtual {
+			rpt.Pdf.SetY(y)
+			if rpt.checkPageBreak(groupHeight) && y > rpt.pageTop {
+				rpt.nextPage()
+				y = rpt.Pdf.GetY()
+				for index, shift := 0, rpt.LeftMargin-colX[0]; index < len(colX); index++ {
+					colX[index] += shift
+				}
+			}
+			for index, tc := range cells {
+				if tc.row < row || tc.row >= end {
+					continue
+				}
+				options[index]["virtual"] = false
+				options[index]["height"] = spanHeight(tc.row, tc.rowspan)
+				rpt.Pdf.SetXY(colX[tc.col], y+spanHeight(row, tc.row-row))
+				rpt.createCell(options[index])
+				xname := ToString(tc.cell.Name, "head")
+				rpt.addToXML("details", []string{xname, options[index]["text"].(string), xname})
+			}
+		}
+		y += groupHeight
+		row = end
+	}
+	rpt.Pdf.SetXY(rpt.LeftMargin, y)
+}
+
 func (rpt *Report) createBarcode(v *Barcode, virtual, ln bool) (float64, float64) {
 	pageWidth, _ := rpt.Pdf.GetPageSize()
 	rpt.Pdf.SetTextColor(int(rpt.TextColor.R), int(rpt.TextColor.G), int(rpt.TextColor.B))
+	paddingLeft, _, paddingRight, _ := rpt.getPadding(IM{})
+	padding := paddingLeft + paddingRight
 	width := v.Width
 	strWidth := rpt.Pdf.GetTextWidth(v.Value)
 	if width == 0 {
-		width = strWidth + 1.5*_padding
+		width = strWidth + 1.5*padding
 	}
 	height := v.Height
 	if height == 0 {
@@ -1119,11 +2412,11 @@ func (rpt *Report) createBarcode(v *Barcode, virtual, ln bool) (float64, float64
 	lineHt := rpt.Pdf.GetFontSize()
 	if ln {
 		if v.Extend {
-			width = pageWidth - startX - rpt.RightMargin - _padding
+			width = pageWidth - startX - rpt.RightMargin - padding
 		}
 	}
 	if rpt.checkPageBreak(height) && !virtual {
-		rpt.addPage()
+		rpt.nextPage()
 	}
 	var bcode barcode.Barcode
 	switch v.CodeType {
@@ -1146,15 +2439,19 @@ func (rpt *Report) createBarcode(v *Barcode, virtual, ln bool) (float64, float64
 	}
 
 	if bcode != nil {
-		buf := new(bytes.Buffer)
-		err := jpeg.Encode(buf, bcode, &jpeg.Options{Quality: 100})
-		if err == nil {
-			rpt.Pdf.AddImage(&Image{Data: buf.Bytes(), Width: width, Height: height}, startX, startY, IM{})
+		if !virtual {
+			buf := new(bytes.Buffer)
+			err := jpeg.Encode(buf, bcode, &jpeg.Options{Quality: 100})
+			if err == nil {
+				rpt.Pdf.AddImage(&Image{Data: buf.Bytes(), Width: width, Height: height}, startX, startY, IM{})
+			}
 		}
 		if v.VisibleValue {
-			rpt.Pdf.SetXY(startX+(width-strWidth)/2, startY+height+1.5*_padding)
-			rpt.Pdf.Text(v.Value, rpt.pageBreak-rpt.footerHeight)
-			height += lineHt + 1.5*_padding
+			if !virtual {
+				rpt.Pdf.SetXY(startX+(width-strWidth)/2, startY+height+1.5*padding)
+				rpt.Pdf.Text(v.Value, rpt.pageBreak-rpt.footerHeight)
+			}
+			height += lineHt + 1.5*padding
 		}
 	}
 	return height, width
@@ -1178,8 +2475,315 @@ func (rpt *Report) addToXML(section string, values []string) {
 	}
 }
 
+// rowAligned returns true if the row or a cell of the row has a vertical alignment
+func rowAligned(rowElement *Row) bool {
+	if rowElement.VAlign != "" {
+		return true
+	}
+	for index := 0; index < len(rowElement.Columns); index++ {
+		if v, valid := rowElement.Columns[index].Item.(*Cell); valid && v.VAlign != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// getRowVAlign returns the height and the text baseline of a row with vertically aligned elements, and the
+// measured heights of the barcodes and boxes by the column index. The height value is the measured height
+// of the row. The baseline is the lowest text baseline of the cells. The images, barcodes and boxes of a
+// baseline aligned row stand on the baseline, so the baseline is at least as low as their height.
+func (rpt *Report) getRowVAlign(rowElement *Row, height float64) (rowHeight, baseline float64, heights map[int]float64) {
+	// the largest distance of the cell bottoms from the baseline
+	descent := float64(0)
+	heights = make(map[int]float64)
+	cx, cy := rpt.Pdf.GetX(), rpt.Pdf.GetY()
+	for index := 0; index < len(rowElement.Columns); index++ {
+		elementHeight := float64(0)
+		switch v := rowElement.Columns[index].Item.(type) {
+		case *Cell:
+			_, paddingTop, _, paddingBottom := rpt.getPadding(IM{
+				"padding": v.Padding, "paddingTop": v.PaddingTop, "paddingBottom": v.PaddingBottom})
+			elementHeight = paddingTop + ToFloat(v.FontSize, rpt.FontSize)
+			if paddingBottom > descent {
+				descent = paddingBottom
+			}
+		case *Image:
+			if rowElement.VAlign == "A" && v.Src != "" {
+				rpt.createImage(v, rowElement.Height, true)
+				elementHeight = v.Height
+			}
+		case *Barcode:
+			if rowElement.VAlign != "" {
+				heights[index], _ = rpt.createBarcode(v, true, false)
+			}
+		case *Box:
+			if rowElement.VAlign != "" {
+				heights[index], _ = rpt.createBox(v, 0, true)
+			}
+		}
+		if rowElement.VAlign == "A" && heights[index] > elementHeight {
+			elementHeight = heights[index]
+		}
+		if elementHeight > baseline {
+			baseline = elementHeight
+		}
+	}
+	rpt.Pdf.SetXY(cx, cy)
+	rowHeight = height
+	if descent > 0 && baseline+descent > rowHeight {
+		rowHeight = baseline + descent
+	}
+	return rowHeight, baseline, heights
+}
+
+// getVAlignOffset returns the vertical distance of an element from the top of the row
+func getVAlignOffset(valign string, rowHeight, baseline, height float64) (offset float64) {
+	switch valign {
+	case "M":
+		offset = (rowHeight - height) / 2
+	case "B":
+		offset = rowHeight - height
+	case "A":
+		offset = baseline - height
+	}
+	if offset < 0 {
+		return 0
+	}
+	return offset
+}
+
+// cellOptions returns the createCell options of a Cell
+func (rpt *Report) cellOptions(v *Cell, virtual bool) IM {
+	return IM{
+		"fontFamily":      v.FontFamily,
+		"fontStyle":       v.FontStyle + v.TextDecoration,
+		"fontSize":        v.FontSize,
+		"textColor":       v.TextColor,
+		"borderColor":     v.BorderColor,
+		"backgroundColor": v.BackgroundColor,
+		"text":            rpt.setValue(v.Value),
+		"width":           v.Width,
+		"border":          v.Border,
+		"align":           v.Align,
+		"direction":       v.Direction,
+		"overflow":        rpt.parseValue("Overflow", v.Overflow),
+		"minFontSize":     v.MinFontSize,
+		"multiline":       false,
+		"extend":          true,
+		"virtual":         virtual,
+		"padding":         v.Padding,
+		"paddingLeft":     v.PaddingLeft,
+		"paddingTop":      v.PaddingTop,
+		"paddingRight":    v.PaddingRight,
+		"paddingBottom":   v.PaddingBottom,
+		"lineHeight":      v.LineHeight,
+	}
+}
+
+// rowPart - a part of a details row split by page breaks
+type rowPart struct {
+	first, last bool
+	//the texts of the multiline cells by the cell index
+	texts map[int]string
+	//the cell options of the measured row by the cell index
+	options map[int]IM
+	//the common height of the cells, or 0
+	height float64
+}
+
 func (rpt *Report) createRow(section string, rowElement *Row, virtual bool) float64 {
+	if rowElement.Position == "absolute" {
+		if !virtual {
+			rpt.createAbsoluteRow(section, rowElement)
+		}
+		return 0
+	}
+	if rowElement.Wrap {
+		return rpt.createWrapRow(section, rowElement, virtual)
+	}
+	if section == "details" && !virtual && !rpt.inBox {
+		return rpt.createSplitRow(rowElement)
+	}
+	return rpt.createRowPart(section, rowElement, virtual, nil)
+}
+
+// getColumnWidth returns the width of a row element printed from the left margin
+func (rpt *Report) getColumnWidth(section string, element interface{}) (width float64) {
+	cx, cy := rpt.Pdf.GetX(), rpt.Pdf.GetY()
+	defer rpt.Pdf.SetXY(cx, cy)
+	rpt.Pdf.SetX(rpt.LeftMargin)
+	switch v := element.(type) {
+	case *Cell:
+		options := rpt.cellOptions(v, true)
+		options["multiline"] = v.Multiline && section == "details"
+		options["ln"] = false
+		rpt.createCell(options)
+		width = rpt.Pdf.GetX() - rpt.LeftMargin
+	case *Image:
+		if v.Src != "" {
+			_, width = rpt.createImage(v, 0, true)
+		}
+	case *Barcode:
+		_, width = rpt.createBarcode(v, true, false)
+		paddingLeft, _, paddingRight, _ := rpt.getPadding(IM{})
+		width += paddingLeft + paddingRight
+	case *Box:
+		_, width = rpt.createBox(v, 0, true)
+	case *Separator:
+		width = v.Gap
+	}
+	return width
+}
+
+// getWrapLines returns the elements of a wrap mode row grouped into lines that fit in the page width.
+// The separators at the breaks of the lines are dropped.
+func (rpt *Report) getWrapLines(section string, rowElement *Row) [][]PageItem {
+	pageWidth, _ := rpt.Pdf.GetPageSize()
+	right := pageWidth - rpt.RightMargin
+	lines := make([][]PageItem, 0)
+	line := make([]PageItem, 0)
+	x := rpt.LeftMargin
+	for index := 0; index < len(rowElement.Columns); index++ {
+		width := rpt.getColumnWidth(section, rowElement.Columns[index].Item)
+		if len(line) > 0 {
+			width += rowElement.HGap
+			if x+width > right+1e-6 {
+				for len(line) > 1 {
+					if _, separator := line[len(line)-1].Item.(*Separator); !separator {
+						break
+					}
+					line = line[:len(line)-1]
+				}
+				lines = append(lines, line)
+				line, x = make([]PageItem, 0), rpt.LeftMargin
+				if _, separator := rowElement.Columns[index].Item.(*Separator); separator {
+					continue
+				}
+				width -= rowElement.HGap
+			}
+		}
+		line = append(line, rowElement.Columns[index])
+		x += width
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// createWrapRow prints or measures a wrap mode row. The lines of the row are printed as separate rows,
+// and the row height is the sum of the line heights.
+func (rpt *Report) createWrapRow(section string, rowElement *Row, virtual bool) (height float64) {
+	for _, columns := range rpt.getWrapLines(section, rowElement) {
+		line := *rowElement
+		line.Wrap, line.Columns = false, columns
+		if section == "details" && !virtual && !rpt.inBox {
+			height += rpt.createSplitRow(&line)
+			continue
+		}
+		startY := rpt.Pdf.GetY()
+		lineHeight := rpt.createRowPart(section, &line, virtual, nil)
+		rpt.Pdf.SetXY(rpt.LeftMargin, startY+lineHeight)
+		height += lineHeight
+	}
+	return height
+}
+
+// createAbsoluteRow prints a row at the X and Y position of the current page. The row does not
+// change the position of the next element, and it is not moved by the page breaks.
+func (rpt *Report) createAbsoluteRow(section string, rowElement *Row) {
+	cx, cy := rpt.Pdf.GetX(), rpt.Pdf.GetY()
+	leftMargin, inBox := rpt.LeftMargin, rpt.inBox
+	rpt.LeftMargin, rpt.inBox = rowElement.X, true
+	rpt.Pdf.SetXY(rowElement.X, rowElement.Y)
+	rpt.createRowPart(section, rowElement, false, nil)
+	rpt.LeftMargin, rpt.inBox = leftMargin, inBox
+	rpt.Pdf.SetXY(cx, cy)
+}
+
+// createSplitRow prints a details row. A row that does not fit in the free space of the page is moved
+// to the next page, or if it contains multiline cells, the cell texts are split at line boundaries
+// across the pages. The borders of the split cells are closed on both sides of the page break.
+func (rpt *Report) createSplitRow(rowElement *Row) float64 {
+	measure := func(part *rowPart) float64 {
+		cx, cy := rpt.Pdf.GetX(), rpt.Pdf.GetY()
+		height := rpt.createRowPart("details", rowElement, true, part)
+		rpt.Pdf.SetXY(cx, cy)
+		return height
+	}
+	part := &rowPart{first: true, last: true, options: make(map[int]IM)}
+	height := measure(part)
+	texts := make(map[int]string)
+	for index, options := range part.options {
+		if ToBoolean(options["multiline"], false) {
+			texts[index] = ToString(options["text"], "")
+		}
+	}
+	split, newPage := false, rpt.Pdf.GetY() == rpt.pageTop
+	for rpt.checkPageBreak(height) {
+		partTexts, restTexts := make(map[int]string), make(map[int]string)
+		fits, rest := true, false
+		for index, text := range texts {
+			options := part.options[index]
+			partText, restText, textFits := rpt.splitCellText(
+				text, ToFloat(options["cellWidth"], 0), rpt.getPageSpace(), options)
+			partTexts[index], restTexts[index] = partText, restText
+			fits, rest = fits && textFits, rest || restText != ""
+		}
+		if fits && rest {
+			part.texts, part.last, part.height = partTexts, false, 0
+			part.height = measure(part)
+			rpt.createRowPart("details", rowElement, false, part)
+			texts, split = restTexts, true
+			part.first, part.last, part.texts, part.height = false, true, texts, 0
+		} else if newPage {
+			// the row does not fit in an empty page
+			break
+		}
+		rpt.nextPage()
+		newPage = true
+		height = measure(part)
+	}
+	if !split {
+		return rpt.createRowPart("details", rowElement, false, nil)
+	}
+	part.height = height
+	return rpt.createRowPart("details", rowElement, false, part)
+}
+
+// createRowPart prints or measures a row. The texts, the borders and the height of the cells are
+// set by the part value of a split row.
+func (rpt *Report) createRowPart(section string, rowElement *Row, virtual bool, part *rowPart) float64 {
 	maxHeight := rowElement.Height
+	rowHeight, baseline, heights := float64(0), float64(0), map[int]float64{}
+	// the images and barcodes are printed on the first part of a split row
+	following := part != nil && !part.first
+	// the backgrounds and the borders of the boxes fill the height of the row
+	boxHeight, box := float64(0), false
+	for index := 0; index < len(rowElement.Columns) && !box; index++ {
+		_, box = rowElement.Columns[index].Item.(*Box)
+	}
+	aligned := rowAligned(rowElement)
+	if !virtual && (aligned || (box && !following)) {
+		// the row is measured once, the height of a split row part is measured by createSplitRow
+		height := float64(0)
+		if part != nil && part.height > 0 {
+			height = part.height
+		} else {
+			cx, cy := rpt.Pdf.GetX(), rpt.Pdf.GetY()
+			height = rpt.createRowPart(section, rowElement, true, part)
+			rpt.Pdf.SetXY(cx, cy)
+		}
+		if aligned {
+			rowHeight, baseline, heights = rpt.getRowVAlign(rowElement, height)
+			if part != nil {
+				rowHeight = part.height
+			}
+		}
+		if box && !following {
+			boxHeight = height
+		}
+	}
 	for index := 0; index < len(rowElement.Columns); index++ {
 		startY := rpt.Pdf.GetY()
 		if rpt.Pdf.GetX() != rpt.LeftMargin {
@@ -1187,25 +2791,18 @@ func (rpt *Report) createRow(section string, rowElement *Row, virtual bool) floa
 		}
 		startX := rpt.Pdf.GetX()
 		ln := len(rowElement.Columns)-1 == index
+		valign := ""
+		if rowHeight > 0 {
+			valign = rowElement.VAlign
+		}
 		element := rowElement.Columns[index].Item
 		switch v := element.(type) {
 		case *Cell:
-			options := IM{
-				"height":          maxHeight,
-				"fontFamily":      rpt.FontFamily,
-				"fontStyle":       v.FontStyle,
-				"fontSize":        v.FontSize,
-				"textColor":       v.TextColor,
-				"borderColor":     v.BorderColor,
-				"backgroundColor": v.BackgroundColor,
-				"text":            rpt.setValue(v.Value),
-				"width":           v.Width,
-				"border":          v.Border,
-				"align":           v.Align,
-				"multiline":       false,
-				"extend":          true,
-				"virtual":         virtual,
+			if rowHeight > 0 {
+				valign = ToString(v.VAlign, rowElement.VAlign)
 			}
+			options := rpt.cellOptions(v, virtual)
+			options["height"] = maxHeight
 			options["ln"] = ln
 			if section == "details" {
 				options["multiline"] = v.Multiline
@@ -1213,17 +2810,46 @@ func (rpt *Report) createRow(section string, rowElement *Row, virtual bool) floa
 					options["height"] = 0
 				}
 			}
+			if valign != "" {
+				options["height"] = rowHeight
+				options["valign"] = valign
+				options["baseline"] = baseline
+			}
+			xmlText := options["text"].(string)
+			if part != nil {
+				if text, found := part.texts[index]; found {
+					options["text"] = text
+				} else if following {
+					options["text"] = ""
+				}
+				options["border"] = splitBorder(v.Border, part.first, part.last)
+				if part.height > 0 {
+					options["height"] = part.height
+				}
+			}
 			cellHeight := rpt.createCell(options)
 			if cellHeight > maxHeight || maxHeight == 0 {
 				maxHeight = cellHeight
 			}
+			if part != nil && part.options != nil {
+				part.options[index] = options
+			}
 
-			var xname = ToString(v.Name, "head")
-			rpt.addToXML(section, []string{xname, options["text"].(string), xname})
+			if !virtual && !following {
+				var xname = ToString(v.Name, "head")
+				rpt.addToXML(section, []string{xname, xmlText, xname})
+			}
 
 		case *Image:
 			if v.Src != "" {
-				height, width := rpt.createImage(v, maxHeight, virtual)
+				if valign != "" {
+					height, _ := rpt.createImage(v, maxHeight, true)
+					rpt.Pdf.SetY(startY + getVAlignOffset(valign, rowHeight, baseline, height))
+				}
+				height, width := rpt.createImage(v, maxHeight, virtual || following)
+				if following {
+					height = 0
+				}
 				if height > maxHeight {
 					maxHeight = height
 				}
@@ -1234,14 +2860,38 @@ func (rpt *Report) createRow(section string, rowElement *Row, virtual bool) floa
 				}
 			}
 		case *Barcode:
-			height, width := rpt.createBarcode(v, virtual, ln)
+			if valign != "" {
+				rpt.Pdf.SetY(startY + getVAlignOffset(valign, rowHeight, baseline, heights[index]))
+			}
+			height, width := rpt.createBarcode(v, virtual || following, ln)
+			if following {
+				height = 0
+			}
 			if height > maxHeight || maxHeight == 0 {
 				maxHeight = height
 			}
 			if len(rowElement.Columns)-1 == index {
 				rpt.Pdf.SetXY(rpt.LeftMargin, startY+maxHeight)
 			} else {
-				rpt.Pdf.SetXY(startX+width+_padding, startY)
+				paddingLeft, _, paddingRight, _ := rpt.getPadding(IM{})
+				rpt.Pdf.SetXY(startX+width+paddingLeft+paddingRight, startY)
+			}
+		case *Box:
+			if valign != "" {
+				rpt.Pdf.SetY(startY + getVAlignOffset(valign, rowHeight, baseline, heights[index]))
+				boxHeight -= rpt.Pdf.GetY() - startY
+			}
+			height, width := rpt.createBox(v, boxHeight, virtual || following)
+			if following {
+				height = 0
+			}
+			if height > maxHeight || maxHeight == 0 {
+				maxHeight = height
+			}
+			if ln {
+				rpt.Pdf.SetXY(rpt.LeftMargin, startY+maxHeight)
+			} else {
+				rpt.Pdf.SetXY(startX+width, startY)
 			}
 		case *Separator:
 			if !virtual {
@@ -1258,12 +2908,19 @@ func (rpt *Report) createRow(section string, rowElement *Row, virtual bool) floa
 	return maxHeight
 }
 
-func (rpt *Report) createHTML(v *HTML) {
-	lineHt := rpt.Pdf.GetFontSize()
+func (rpt *Report) createHTML(v *HTML, virtual bool) {
+	lineHt := rpt.getLineHeight(IM{"lineHeight": v.LineHeight}, rpt.Pdf.GetFontSize())
+	paddingBottom := ToString(v.PaddingBottom, v.Padding)
+	if paddingBottom == "" {
+		paddingBottom = ToString(_padding, "")
+	}
+	paddingLeft, paddingTop, paddingRight, _ := rpt.getPadding(IM{
+		"padding": ToString(v.Padding, "0"), "paddingLeft": v.PaddingLeft, "paddingTop": v.PaddingTop, "paddingRight": v.PaddingRight})
+	pageWidth, _ := rpt.Pdf.GetPageSize()
 	htmlStr := v.Value
 	fieldname := ToString(v.Fieldname, "head")
 	options := IM{
-		"fontFamily":      rpt.FontFamily,
+		"fontFamily":      v.FontFamily,
 		"fontStyle":       rpt.FontStyle,
 		"fontSize":        rpt.FontSize,
 		"textColor":       rpt.TextColor,
@@ -1271,8 +2928,14 @@ func (rpt *Report) createHTML(v *HTML) {
 		"backgroundColor": rpt.BackgroundColor}
 	htmlStr = rpt.setHTMLValue(htmlStr, fieldname)
 	rpt.setPageStyle(options)
-	rpt.writeHTML(lineHt, htmlStr)
-	rpt.Pdf.SetXY(rpt.LeftMargin, rpt.Pdf.GetY()+lineHt+_padding)
+	if rpt.Pdf.GetX() < rpt.LeftMargin+paddingLeft {
+		rpt.Pdf.SetX(rpt.LeftMargin + paddingLeft)
+	}
+	rpt.Pdf.SetY(rpt.Pdf.GetY() + paddingTop)
+	rpt.writeHTML(lineHt, htmlStr, IM{
+		"align": v.Align, "fontFamily": v.FontFamily, "direction": ToString(v.Direction, rpt.Direction),
+		"left": rpt.LeftMargin + paddingLeft, "right": pageWidth - rpt.RightMargin - paddingRight, "virtual": virtual})
+	rpt.Pdf.SetXY(rpt.LeftMargin, rpt.Pdf.GetY()+lineHt+ToFloat(paddingBottom, _padding))
 }
 
 func (rpt *Report) createLine(v *HLine, virtual bool) {
@@ -1297,31 +2960,193 @@ func (rpt *Report) createLine(v *HLine, virtual bool) {
 	}
 }
 
-func (rpt *Report) createElement(section string, element interface{}) {
+// rowVisible returns false if the Visible data source of the row is an empty list
+func (rpt *Report) rowVisible(rowElement *Row) bool {
+	if rowElement.Visible != "" {
+		if _, found := rpt.data[rowElement.Visible]; found {
+			if srows, valid := rpt.data[rowElement.Visible].([]SM); !valid || len(srows) == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// keepWithNext returns the KeepWithNext value of a page element
+func keepWithNext(element interface{}) bool {
 	switch v := element.(type) {
 	case *Row:
-		if v.Visible != "" {
-			if _, found := rpt.data[v.Visible]; found {
-				if srows, valid := rpt.data[v.Visible].([]SM); !valid || len(srows) == 0 {
-					return
-				}
+		return v.KeepWithNext
+	case *HLine:
+		return v.KeepWithNext
+	case *HTML:
+		return v.KeepWithNext
+	case *Datagrid:
+		return v.KeepWithNext
+	case *Table:
+		return v.KeepWithNext
+	}
+	return false
+}
+
+// getElementHeight returns the printed height of a page element. If the first value is true, it
+// returns the height of the beginning of the element: the first line of an HTML text, the header and
+// the first MinRows (at least one) rows of a Datagrid, or the first element of a Group.
+func (rpt *Report) getElementHeight(element interface{}, first bool) (height float64) {
+	cx, cy := rpt.Pdf.GetX(), rpt.Pdf.GetY()
+	defer rpt.Pdf.SetXY(cx, cy)
+	switch v := element.(type) {
+	case *Row:
+		if rpt.rowVisible(v) {
+			height = rpt.createRow("details", v, true)
+		}
+	case *VGap:
+		if !v.PageBreak {
+			height = v.Height
+		}
+	case *HLine:
+		height = v.Gap
+	case *HTML:
+		if first {
+			_, paddingTop, _, _ := rpt.getPadding(IM{"padding": ToString(v.Padding, "0"), "paddingTop": v.PaddingTop})
+			height = paddingTop + rpt.getLineHeight(IM{"lineHeight": v.LineHeight}, rpt.Pdf.GetFontSize())
+		} else {
+			rpt.createHTML(v, true)
+			height = rpt.Pdf.GetY() - cy
+		}
+	case *Datagrid:
+		rows, _ := rpt.data[v.Databind].([]SM)
+		if minRows := v.MinRows; first && len(rows) > 1 {
+			if minRows < 1 {
+				minRows = 1
+			}
+			if minRows < len(rows) {
+				rows = rows[:minRows]
 			}
 		}
+		if rpt.createGridRows(v, rows, true) {
+			height = rpt.Pdf.GetY() - cy
+		}
+	case *Table:
+		rpt.createTable(v, true)
+		height = rpt.Pdf.GetY() - cy
+	case *Group:
+		if first && !v.KeepTogether && len(v.Elements) > 0 {
+			return rpt.getElementHeight(v.Elements[0].Item, true)
+		}
+		for index := 0; index < len(v.Elements); index++ {
+			height += rpt.getElementHeight(v.Elements[index].Item, false)
+		}
+	}
+	return height
+}
+
+// getKeepHeight returns the height of the keep-with-next elements and the beginning of the next element
+func (rpt *Report) getKeepHeight(elements []PageItem) (height float64) {
+	for index := 0; index < len(elements); index++ {
+		before, after := pageBreaks(elements[index].Item)
+		if before && index > 0 {
+			return height
+		}
+		if after || !keepWithNext(elements[index].Item) {
+			return height + rpt.getElementHeight(elements[index].Item, true)
+		}
+		height += rpt.getElementHeight(elements[index].Item, false)
+	}
+	return height
+}
+
+// keepTogether adds a new page if the height does not fit in the free space of the current page,
+// but it fits in an empty page
+func (rpt *Report) keepTogether(height float64) {
+	if height > 0 && rpt.checkPageBreak(height) && rpt.Pdf.GetY() > rpt.pageTop &&
+		height <= rpt.pageBreak-rpt.footerHeight-rpt.pageTop {
+		rpt.nextPage()
+	}
+}
+
+// pageBreaks returns the PageBreakBefore and PageBreakAfter values of a page element
+func pageBreaks(element interface{}) (before, after bool) {
+	switch v := element.(type) {
+	case *Row:
+		return v.PageBreakBefore, v.PageBreakAfter
+	case *VGap:
+		return v.PageBreakBefore, v.PageBreakAfter
+	case *HLine:
+		return v.PageBreakBefore, v.PageBreakAfter
+	case *HTML:
+		return v.PageBreakBefore, v.PageBreakAfter
+	case *Datagrid:
+		return v.PageBreakBefore, v.PageBreakAfter
+	case *Group:
+		return v.PageBreakBefore, v.PageBreakAfter
+	case *Table:
+		return v.PageBreakBefore, v.PageBreakAfter
+	}
+	return false, false
+}
+
+// createElements prints the page elements. The keep-with-next elements are moved to the next page
+// together with the beginning of the next element. The page break before an element is not added to
+// an empty page, and the break after the last element of the report is omitted.
+func (rpt *Report) createElements(section string, elements []PageItem) {
+	for index := 0; index < len(elements); index++ {
+		if section != "details" {
+			rpt.createElement(section, elements[index].Item)
+			continue
+		}
+		// a section starts on its own new page
+		if v, valid := elements[index].Item.(*Section); valid {
+			rpt.createSection(v)
+			continue
+		}
+		before, after := pageBreaks(elements[index].Item)
+		if rpt.breakAfter || (before && rpt.Pdf.GetY() > rpt.pageTop) {
+			rpt.addPage()
+		}
+		rpt.breakAfter = false
+		if keepWithNext(elements[index].Item) {
+			rpt.keepTogether(rpt.getKeepHeight(elements[index:]))
+		}
+		rpt.createElement(section, elements[index].Item)
+		rpt.breakAfter = rpt.breakAfter || after
+	}
+}
+
+func (rpt *Report) createElement(section string, element interface{}) {
+	switch v := element.(type) {
+	case *Row:
+		if !rpt.rowVisible(v) {
+			return
+		}
 		rpt.createRow(section, v, false)
 	case *VGap:
 		if v.PageBreak {
 			rpt.addPage()
 		}
 		if rpt.checkPageBreak(v.Height) {
-			rpt.addPage()
+			rpt.nextPage()
 		}
 		rpt.Pdf.Ln(v.Height)
 	case *HLine:
 		rpt.createLine(v, false)
 	case *HTML:
-		rpt.createHTML(v)
+		rpt.createHTML(v, false)
 	case *Datagrid:
 		rpt.createDatagrid(v, false)
+	case *Group:
+		if v.KeepTogether {
+			rpt.keepTogether(rpt.getElementHeight(v, false))
+		}
+		rpt.createElements(section, v.Elements)
+	case *Table:
+		rpt.createTable(v, false)
+	case *Labels:
+		rpt.createLabels(v)
+	case *NeedSpace:
+		if rpt.checkPageBreak(v.Height) && rpt.Pdf.GetY() > rpt.pageTop {
+			rpt.nextPage()
+		}
 	}
 }
 
@@ -1329,7 +3154,7 @@ func (rpt *Report) setPageStyle(options IM) {
 	//font-family, font-size, font-style, color,background-color,border-color
 	fontStyle := ToString(options["fontStyle"], "")
 	fontSize := ToFloat(options["fontSize"], rpt.FontSize)
-	rpt.Pdf.SetFont(rpt.FontFamily, fontStyle, fontSize)
+	rpt.setPdfFont(ToString(options["fontFamily"], ""), fontStyle, fontSize)
 
 	if textColor, textKey := options["textColor"]; textKey {
 		rpt.Pdf.SetTextColor(int(textColor.(color.RGBA).R), int(textColor.(color.RGBA).G), int(textColor.(color.RGBA).B))
@@ -1343,39 +3168,180 @@ func (rpt *Report) setPageStyle(options IM) {
 
 }
 
+// parseLength returns a length value in points. The value can have a unit suffix: "pt", "mm", "cm" or "in",
+// the unit of the value without suffix is the unit parameter.
+func parseLength(value string, unit float64) (float64, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for suffix, pt := range unitPt {
+		if strings.HasSuffix(value, suffix) {
+			value, unit = strings.TrimSpace(strings.TrimSuffix(value, suffix)), pt
+			break
+		}
+	}
+	length, err := strconv.ParseFloat(value, 64)
+	return length * unit, err == nil
+}
+
+// getLength returns a template length value in points. The value can have a unit suffix ("pt", "mm", "cm" or "in"),
+// the unit of the values without suffix is the unit parameter. The defValue is returned for an invalid value,
+// the zero value is valid.
+func getLength(value interface{}, unit, defValue float64) float64 {
+	switch v := value.(type) {
+	case string:
+		if length, valid := parseLength(v, unit); valid {
+			return length
+		}
+	case float64, float32, int, int32, int64:
+		return ToFloat(v, 0) * unit
+	}
+	return defValue
+}
+
+// getFontSize returns a valid (positive) font size value or the defValue
+func getFontSize(value interface{}, defValue float64) float64 {
+	if size := getLength(value, 1, defValue); size > 0 {
+		return size
+	}
+	return defValue
+}
+
+// getUnit returns the points of the report unit
+func (rpt *Report) getUnit() float64 {
+	if pt, found := unitPt[rpt.Unit]; found {
+		return pt
+	}
+	return _mmPt
+}
+
+// getPageFormat returns the portrait page size in points of a named format or a custom "width x height"
+// size with optional unit suffixes (e.g. "80x200mm", "4in x 6in", "612x792pt"). The default unit is mm.
+func getPageFormat(format string) (width, height float64, valid bool) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if size, found := pageFormats[format]; found {
+		return size[0] * _mmPt, size[1] * _mmPt, true
+	}
+	unit := _mmPt
+	for suffix, pt := range unitPt {
+		if strings.HasSuffix(format, suffix) {
+			unit = pt
+			break
+		}
+	}
+	values := strings.FieldsFunc(format, func(r rune) bool { return r == 'x' || r == '×' })
+	if len(values) != 2 {
+		return 0, 0, false
+	}
+	width, wvalid := parseLength(values[0], unit)
+	height, hvalid := parseLength(values[1], unit)
+	return width, height, wvalid && hvalid && width > 0 && height > 0
+}
+
 func (rpt *Report) parseValue(vname string, value interface{}) interface{} {
 
 	parseStringMap := func(value interface{}, defValue string) interface{} {
 		svalue := ToString(value, defValue)
 		valid := SM{
-			"R": "R", "C": "C", "L": "L", "J": "J", "left": "L", "center": "C", "right": "R", "justify": "L",
+			"R": "R", "C": "C", "L": "L", "J": "J", "left": "L", "center": "C", "right": "R", "justify": "J",
 			"B": "B", "I": "I", "BI": "BI", "IB": "IB", "bold": "B", "italic": "I", "bolditalic": "BI", "normal": "",
 			"p": "p", "l": "l", "portrait": "p", "landscape": "l",
-			"a3": "a3", "a4": "a4", "a5": "a5", "letter": "letter", "legal": "legal",
 		}
 		if _, found := valid[svalue]; found {
 			return valid[svalue]
 		}
 		return defValue
 	}
+	// the horizontal alignment values with the "inside" and "outside" page alignments
+	parseAlign := func(value interface{}) interface{} {
+		if align, found := (SM{"inside": "I", "outside": "O", "I": "I", "O": "O"})[ToString(value, "")]; found {
+			return align
+		}
+		return parseStringMap(value, _align)
+	}
 	checkValue := map[string]func(value interface{}) interface{}{
 		"Format": func(value interface{}) interface{} {
-			return parseStringMap(value, _format)
+			format := strings.ToLower(ToString(value, _format))
+			if _, found := pageFormats[format]; found {
+				return format
+			}
+			if width, height, valid := getPageFormat(format); valid {
+				return ToString(width, "") + "x" + ToString(height, "") + "pt"
+			}
+			return _format
 		},
 		"Orientation": func(value interface{}) interface{} {
 			return parseStringMap(value, _orientation)
 		},
 		"FontSize": func(value interface{}) interface{} {
-			return ToFloat(value, rpt.FontSize)
+			return getFontSize(value, rpt.FontSize)
 		},
 		"Height": func(value interface{}) interface{} {
-			return ToFloat(value, 0) * _mmPt
+			return getLength(value, rpt.getUnit(), 0)
 		},
 		"Gap": func(value interface{}) interface{} {
-			return ToFloat(value, 0) * _mmPt
+			return getLength(value, rpt.getUnit(), 0)
 		},
 		"HGap": func(value interface{}) interface{} {
-			return ToFloat(value, 0) * _mmPt
+			return getLength(value, rpt.getUnit(), 0)
+		},
+		"X": func(value interface{}) interface{} {
+			return getLength(value, rpt.getUnit(), 0)
+		},
+		"LeftMargin": func(value interface{}) interface{} {
+			return getLength(value, rpt.getUnit(), 0)
+		},
+		"RightMargin": func(value interface{}) interface{} {
+			return getLength(value, rpt.getUnit(), 0)
+		},
+		"TopMargin": func(value interface{}) interface{} {
+			return getLength(value, rpt.getUnit(), 0)
+		},
+		"BottomMargin": func(value interface{}) interface{} {
+			return getLength(value, rpt.getUnit(), 0)
+		},
+		"Left": func(value interface{}) interface{} {
+			return getLength(value, rpt.getUnit(), 0)
+		},
+		"Top": func(value interface{}) interface{} {
+			return getLength(value, rpt.getUnit(), 0)
+		},
+		"VGap": func(value interface{}) interface{} {
+			return getLength(value, rpt.getUnit(), 0)
+		},
+		"Y": func(value interface{}) interface{} {
+			return getLength(value, rpt.getUnit(), 0)
+		},
+		"Position": func(value interface{}) interface{} {
+			position := SM{"absolute": "absolute"}
+			return ToString(position[ToString(value, "")], "")
+		},
+		"Padding": func(value interface{}) interface{} {
+			return ToString(getLength(value, rpt.getUnit(), 0), "0")
+		},
+		"PaddingLeft": func(value interface{}) interface{} {
+			return ToString(getLength(value, rpt.getUnit(), 0), "0")
+		},
+		"PaddingTop": func(value interface{}) interface{} {
+			return ToString(getLength(value, rpt.getUnit(), 0), "0")
+		},
+		"PaddingRight": func(value interface{}) interface{} {
+			return ToString(getLength(value, rpt.getUnit(), 0), "0")
+		},
+		"PaddingBottom": func(value interface{}) interface{} {
+			return ToString(getLength(value, rpt.getUnit(), 0), "0")
+		},
+		"LineHeight": func(value interface{}) interface{} {
+			return ToString(getLength(value, rpt.getUnit(), 0), "0")
+		},
+		"Widths": func(value interface{}) interface{} {
+			widths := strings.Split(ToString(value, ""), ",")
+			for index, width := range widths {
+				width = strings.TrimSpace(width)
+				if width != "" && !strings.HasSuffix(width, "%") {
+					width = ToString(getLength(width, rpt.getUnit(), 0), "0")
+				}
+				widths[index] = width
+			}
+			return strings.Join(widths, ",")
 		},
 		"Width": func(value interface{}) interface{} {
 			switch v := value.(type) {
@@ -1388,10 +3354,10 @@ func (rpt *Report) parseValue(vname string, value interface{}) interface{} {
 					}
 					return ToString(ivalue, "0") + "%"
 				}
-				return ToString(ToFloat(value, 0)*_mmPt, "0")
+				return ToString(getLength(value, rpt.getUnit(), 0), "0")
 
 			default:
-				return ToString(ToFloat(value, 0)*_mmPt, "0")
+				return ToString(getLength(value, rpt.getUnit(), 0), "0")
 			}
 		},
 		"Merge": func(value interface{}) interface{} {
@@ -1427,16 +3393,41 @@ func (rpt *Report) parseValue(vname string, value interface{}) interface{} {
 			return ToRGBA(value, rpt.BackgroundColor)
 		},
 		"FontStyle": func(value interface{}) interface{} {
+			fontStyle := ToString(value, _fontStyle)
+			decoration := SM{"underline": "U", "strikethrough": "S", "line-through": "S"}
+			if _, found := decoration[fontStyle]; found {
+				return decoration[fontStyle]
+			}
+			if fontStyle != "" && strings.Trim(fontStyle, "BIUS") == "" {
+				return fontStyle
+			}
 			return parseStringMap(value, _fontStyle)
 		},
-		"Align": func(value interface{}) interface{} {
-			return parseStringMap(value, _align)
+		"Overflow": func(value interface{}) interface{} {
+			return overflowModes[ToString(value, "")]
+		},
+		"MinFontSize": func(value interface{}) interface{} {
+			return getFontSize(value, 0)
+		},
+		"TextDecoration": func(value interface{}) interface{} {
+			decoration := SM{"underline": "U", "line-through": "S", "strikethrough": "S"}
+			textDecoration := ""
+			for _, field := range strings.Fields(ToString(value, "")) {
+				textDecoration += decoration[field]
+			}
+			return textDecoration
 		},
-		"HeaderAlign": func(value interface{}) interface{} {
-			return parseStringMap(value, _align)
+		"Align":       parseAlign,
+		"HeaderAlign": parseAlign,
+		"VAlign": func(value interface{}) interface{} {
+			valign := SM{
+				"T": "T", "M": "M", "B": "B", "A": "A", "top": "T", "middle": "M", "bottom": "B", "baseline": "A"}
+			return ToString(valign[ToString(value, "")], "")
 		},
-		"FooterAlign": func(value interface{}) interface{} {
-			return parseStringMap(value, _align)
+		"FooterAlign": parseAlign,
+		"Direction": func(value interface{}) interface{} {
+			direction := SM{"ltr": "ltr", "rtl": "rtl", "LTR": "ltr", "RTL": "rtl"}
+			return ToString(direction[ToString(value, "")], "")
 		},
 	}
 
@@ -1446,78 +3437,371 @@ func (rpt *Report) parseValue(vname string, value interface{}) interface{} {
 	return value
 }
 
-func (rpt *Report) setFont() bool {
-	fontFile := map[string]func(family string) string{
-		"REGULAR": func(family string) string {
-			return family + "-Regular.ttf"
-		},
-		"BOLD": func(family string) string {
-			return family + "-Bold.ttf"
-		},
-		"ITALIC": func(family string) string {
-			return family + "-Italic.ttf"
-		},
-		"BOLDITALIC": func(family string) string {
-			return family + "-BoldItalic.ttf"
-		},
+// fontFiles - the font file names of the font styles
+var fontFiles = map[string]func(family string) string{
+	"": func(family string) string {
+		return family + "-Regular.ttf"
+	},
+	"B": func(family string) string {
+		return family + "-Bold.ttf"
+	},
+	"I": func(family string) string {
+		return family + "-Italic.ttf"
+	},
+	"BI": func(family string) string {
+		return family + "-BoldItalic.ttf"
+	},
+}
+
+// fontStyles - the font style keys of a font family
+var fontStyles = []string{"", "B", "I", "BI"}
+
+// fontFallback - the registered font styles substituting a missing font style
+var fontFallback = map[string][]string{
+	"": {"", "B", "I", "BI"}, "B": {"B", "", "BI", "I"}, "I": {"I", "", "BI", "B"}, "BI": {"BI", "B", "I", ""},
+}
+
+func fontErr(family, style, msg string) error {
+	return fmt.Errorf("font %s (style: %q): %s", family, style, msg)
+}
+
+/*
+AddFontFamily - Register a font family from TTF files. The fontDir directory must contain
+the Family-Regular.ttf file, the Family-Bold.ttf, Family-Italic.ttf and Family-BoldItalic.ttf files
+are optional. The missing font styles fall back to the registered styles of the family.
+The registered font family can be used in the font-family attribute of the elements.
+
+Example:
+
+	err := rpt.AddFontFamily("NotoSansMono", "data/fonts")
+*/
+func (rpt *Report) AddFontFamily(family, fontDir string) error {
+	styles, fonts := make([]string, 0), make(map[string][]byte)
+	for _, style := range fontStyles {
+		fileName := path.Join(fontDir, fontFiles[style](family))
+		if _, err := os.Stat(fileName); err != nil {
+			if style == "" {
+				return fontErr(family, style, "missing font file "+fileName)
+			}
+			continue
+		}
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			return fontErr(family, style, err.Error())
+		}
+		if err = checkFontData(data); err != nil {
+			return fontErr(family, style, err.Error())
+		}
+		styles, fonts[style] = append(styles, style), data
+	}
+	// the fonts are added to the generator and the family is registered only if all of its font files are valid
+	for _, style := range styles {
+		if _, err := rpt.loadFont(family, style, bytes.NewReader(fonts[style])); err != nil {
+			return err
+		}
 	}
+	for _, style := range styles {
+		rpt.addFontStyle(family, style)
+	}
+	return nil
+}
 
-	checkCustom := func() bool {
-		if _, err := os.Stat(path.Join(rpt.fontDir, fontFile["REGULAR"](rpt.FontFamily))); err != nil {
-			return false
+// checkFontData returns an error if the data is not a valid TTF font
+func checkFontData(data []byte) error {
+	parser := core.TTFParser{}
+	return parser.ParseFontData(data)
+}
+
+// AddFontFile - Register a TTF font file with the family name and font style ("", "B", "I" or "BI").
+func (rpt *Report) AddFontFile(family, style, fileName string) error {
+	font, err := os.Open(fileName)
+	if err != nil {
+		return fontErr(family, style, err.Error())
+	}
+	defer font.Close()
+	return rpt.AddFontReader(family, style, font)
+}
+
+/*
+AddFontFS - Register a TTF font from a file system (e.g. embed.FS) with the family name and
+font style ("", "B", "I" or "BI").
+
+Example:
+
+	//go:embed fonts
+	var fonts embed.FS
+
+	err := rpt.AddFontFS(fonts, "NotoSansMono", "B", "fonts/NotoSansMono-Bold.ttf")
+*/
+func (rpt *Report) AddFontFS(fsys fs.FS, family, style, name string) error {
+	font, err := fsys.Open(name)
+	if err != nil {
+		return fontErr(family, style, err.Error())
+	}
+	defer font.Close()
+	return rpt.AddFontReader(family, style, font)
+}
+
+// AddFontData - Register TTF font data with the family name and font style ("", "B", "I" or "BI").
+func (rpt *Report) AddFontData(family, style string, data []byte) error {
+	return rpt.AddFontReader(family, style, bytes.NewReader(data))
+}
+
+// AddFontReader - Register a TTF font with the family name and font style ("", "B", "I" or "BI").
+func (rpt *Report) AddFontReader(family, style string, rd io.Reader) error {
+	key, err := rpt.loadFont(family, style, rd)
+	if err != nil {
+		return err
+	}
+	rpt.addFontStyle(family, key)
+	return nil
+}
+
+// fontStyleKeys - the font style keys of the valid font style values
+var fontStyleKeys = SM{
+	"": "", "normal": "", "regular": "", "b": "B", "bold": "B", "i": "I", "italic": "I",
+	"bi": "BI", "ib": "BI", "bolditalic": "BI", "italicbold": "BI",
+}
+
+// loadFont imports a TTF font into the generator and returns the font style key.
+// The font is not added to the registered font families.
+func (rpt *Report) loadFont(family, style string, rd io.Reader) (string, error) {
+	if family == "" {
+		return "", fontErr(family, style, "missing family name")
+	}
+	key, valid := fontStyleKeys[strings.ToLower(style)]
+	if !valid {
+		return "", fontErr(family, style, "invalid font style")
+	}
+	if err := rpt.Pdf.AddFont(family, key, "", rd); err != nil {
+		return "", fontErr(family, key, err.Error())
+	}
+	return key, nil
+}
+
+// addFontStyle - add a registered font style of the family
+func (rpt *Report) addFontStyle(family, style string) {
+	if rpt.fonts == nil {
+		rpt.fonts = make(map[string]SM)
+	}
+	if _, found := rpt.fonts[family]; !found {
+		rpt.fonts[family] = SM{}
+	}
+	rpt.fonts[family][style] = style
+}
+
+// getFontFamily returns the family value if it is a registered font family, otherwise the Report.FontFamily.
+// An unregistered family value is reported by the FontError function.
+func (rpt *Report) getFontFamily(family string) string {
+	if _, found := rpt.fonts[family]; found {
+		return family
+	}
+	if family != "" && rpt.fontError == nil {
+		rpt.fontError = fontErr(family, "", "unregistered font family, the "+rpt.FontFamily+" font family is used")
+	}
+	return rpt.FontFamily
+}
+
+// checkFontFamily returns an error if the value of a font family field is not a registered font family
+func (rpt *Report) checkFontFamily(fieldname string, value interface{}) error {
+	if propMap[strings.ToLower(fieldname)] != "FontFamily" {
+		return nil
+	}
+	family := ToString(value, "")
+	if _, found := rpt.fonts[family]; !found && family != "" {
+		return fontErr(family, "", "unregistered font family")
+	}
+	return nil
+}
+
+// checkOverflow returns an error if the overflow value is not a valid overflow mode
+func checkOverflow(fieldname string, value interface{}) error {
+	if propMap[strings.ToLower(fieldname)] != "Overflow" {
+		return nil
+	}
+	if _, found := overflowModes[ToString(value, "")]; !found {
+		return fmt.Errorf("invalid overflow mode: %s", ToString(value, ""))
+	}
+	return nil
+}
+
+// FontError returns the first font family error of the report: the custom font family of New
+// can not be registered, or an element uses an unregistered font family. The Report.FontFamily
+// is used instead of the failed font families.
+func (rpt *Report) FontError() error {
+	return rpt.fontError
+}
+
+// fontStyleKey returns the font style key ("", "B", "I" or "BI") of a style value
+func fontStyleKey(style string) (key string) {
+	if strings.Contains(style, "B") {
+		key += "B"
+	}
+	if strings.Contains(style, "I") {
+		key += "I"
+	}
+	return key
+}
+
+// getFontStyle returns the registered style of the font family substituting the style value.
+// The text decoration flags ("U" underline, "S" strike-through) are kept.
+func (rpt *Report) getFontStyle(family, style string) string {
+	key := fontStyleKey(style)
+	if styles, found := rpt.fonts[family]; found {
+		for _, fallback := range fontFallback[key] {
+			if _, found := styles[fallback]; found {
+				key = fallback
+				break
+			}
 		}
-		if _, err := os.Stat(path.Join(rpt.fontDir, fontFile["BOLD"](rpt.FontFamily))); err != nil {
-			return false
+	}
+	for _, decoration := range []string{"U", "S"} {
+		if strings.Contains(style, decoration) {
+			key += decoration
 		}
-		if _, err := os.Stat(path.Join(rpt.fontDir, fontFile["ITALIC"](rpt.FontFamily))); err != nil {
-			return false
+	}
+	return key
+}
+
+/*
+SetFallbackFonts - Set the registered font families of the fallback font chain. If the text contains
+characters missing from the current font, the first fallback font containing the glyphs is used.
+
+Example:
+
+	err := rpt.AddFontFamily("NotoSansCJK", "data/fonts")
+	err = rpt.SetFallbackFonts("NotoSansCJK")
+*/
+func (rpt *Report) SetFallbackFonts(families ...string) error {
+	for _, family := range families {
+		if _, found := rpt.fonts[family]; !found {
+			return fontErr(family, "", "unregistered font family")
 		}
-		if _, err := os.Stat(path.Join(rpt.fontDir, fontFile["BOLDITALIC"](rpt.FontFamily))); err != nil {
-			return false
+	}
+	rpt.FallbackFonts = strings.Join(families, ",")
+	return nil
+}
+
+// getFallbackFonts returns the registered font families and styles of the fallback font chain
+func (rpt *Report) getFallbackFonts(style string) (faces []fontFace) {
+	for _, family := range strings.Split(rpt.FallbackFonts, ",") {
+		family = strings.TrimSpace(family)
+		if _, found := rpt.fonts[family]; found {
+			faces = append(faces, fontFace{family: family, style: rpt.getFontStyle(family, style)})
 		}
-		return true
 	}
+	return faces
+}
 
+// setPdfFont sets the registered font family and style of the generator
+func (rpt *Report) setPdfFont(family, style string, size float64) {
+	family = rpt.getFontFamily(family)
+	rpt.Pdf.SetFont(family, rpt.getFontStyle(family, style), size)
+}
+
+func (rpt *Report) setFont() bool {
 	custom := false
-	if rpt.FontFamily != _fontFamily && rpt.fontDir != "" {
-		custom = checkCustom()
+	if rpt.FontFamily != _fontFamily {
+		err := fontErr(rpt.FontFamily, "", "missing font directory")
+		if rpt.fontDir != "" {
+			err = rpt.AddFontFamily(rpt.FontFamily, rpt.fontDir)
+		}
+		custom = (err == nil)
+		if !custom {
+			rpt.fontError = fmt.Errorf("%w, the %s font family is used", err, _fontFamily)
+		}
 	}
 
-	if custom {
-		rpt.Pdf.AddFont(rpt.FontFamily, "", path.Join(rpt.fontDir, fontFile["REGULAR"](rpt.FontFamily)), nil)
-		rpt.Pdf.AddFont(rpt.FontFamily, "B", path.Join(rpt.fontDir, fontFile["BOLD"](rpt.FontFamily)), nil)
-		rpt.Pdf.AddFont(rpt.FontFamily, "I", path.Join(rpt.fontDir, fontFile["ITALIC"](rpt.FontFamily)), nil)
-		rpt.Pdf.AddFont(rpt.FontFamily, "BI", path.Join(rpt.fontDir, fontFile["BOLDITALIC"](rpt.FontFamily)), nil)
-	} else {
+	if !custom {
 		rpt.FontFamily = _fontFamily
-		font, _ := Fonts.Open(path.Join("fonts", fontFile["REGULAR"](_fontFamily)))
-		rpt.Pdf.AddFont(rpt.FontFamily, "", "", font)
-		font, _ = Fonts.Open(path.Join("fonts", fontFile["BOLD"](_fontFamily)))
-		rpt.Pdf.AddFont(rpt.FontFamily, "B", "", font)
-		font, _ = Fonts.Open(path.Join("fonts", fontFile["ITALIC"](_fontFamily)))
-		rpt.Pdf.AddFont(rpt.FontFamily, "I", "", font)
-		font, _ = Fonts.Open(path.Join("fonts", fontFile["BOLDITALIC"](_fontFamily)))
-		rpt.Pdf.AddFont(rpt.FontFamily, "BI", "", font)
+		for _, style := range fontStyles {
+			_ = rpt.AddFontFS(Fonts, _fontFamily, style, path.Join("fonts", fontFiles[style](_fontFamily)))
+		}
 	}
 	return true
 }
 
 func (rpt *Report) addPage() {
+	// a continuous report has a single page
+	if rpt.Continuous && rpt.Pdf.PageNo() > 0 {
+		return
+	}
 	rpt.Pdf.AddPage()
+	if rpt.Columns > 1 || rpt.MirrorMargins || rpt.Gutter > 0 {
+		rpt.LeftMargin, rpt.RightMargin = rpt.getPageMargins(rpt.Pdf.PageNo())
+	}
+	header, footer := rpt.getPageSections(rpt.Pdf.PageNo())
+	if rpt.labelPage {
+		header, footer = nil, nil
+	}
+	rpt.footerHeight = rpt.getFooterHeight(footer)
 	rpt.Pdf.SetXY(rpt.LeftMargin, rpt.TopMargin)
-	rpt.createHeaderAndFooter()
+	rpt.createHeaderAndFooter(header, footer)
+	// the line break of the header moves to the left margin of the generator, not to the section margin
+	rpt.Pdf.SetX(rpt.LeftMargin)
+	rpt.pageTop = rpt.Pdf.GetY()
+	rpt.setColumn(0)
 }
 
-func (rpt *Report) onPage() {
+// getPageMargins returns the left and right margins of a page. The gutter is added to the inner margin,
+// and the inner and outer margins are swapped on the even pages if the margins are mirrored.
+func (rpt *Report) getPageMargins(pageNo int) (left, right float64) {
+	if rpt.MirrorMargins && pageNo%2 == 0 {
+		return rpt.pageRight, rpt.pageLeft + rpt.Gutter
+	}
+	return rpt.pageLeft + rpt.Gutter, rpt.pageRight
+}
+
+// pageAlign returns the left or right alignment of the "I" (inside) and "O" (outside) alignments on the current page.
+// The inside is the left side of the pages, and the right side of the even pages if the margins are mirrored.
+func (rpt *Report) pageAlign(align string) string {
+	if align != "I" && align != "O" {
+		return align
+	}
+	if (align == "I") == (rpt.MirrorMargins && rpt.Pdf.PageNo()%2 == 0) {
+		return "R"
+	}
+	return "L"
+}
+
+// setColumn sets the margins of a details column of the multi-column layout
+func (rpt *Report) setColumn(column int) {
+	if rpt.Columns < 2 {
+		return
+	}
+	pageWidth, _ := rpt.Pdf.GetPageSize()
+	pageLeft, pageRight := rpt.getPageMargins(rpt.Pdf.PageNo())
+	width := (pageWidth - pageLeft - pageRight - float64(rpt.Columns-1)*rpt.ColumnGap) / float64(rpt.Columns)
+	rpt.column = column
+	rpt.LeftMargin = pageLeft + float64(column)*(width+rpt.ColumnGap)
+	rpt.RightMargin = pageWidth - rpt.LeftMargin - width
+	rpt.Pdf.SetXY(rpt.LeftMargin, rpt.pageTop)
+}
+
+// nextPage continues the details in the next column of the multi-column layout,
+// or on a new page after the last column
+func (rpt *Report) nextPage() {
+	if rpt.Columns > 1 && rpt.column < rpt.Columns-1 {
+		rpt.setColumn(rpt.column + 1)
+		return
+	}
 	rpt.addPage()
 }
 
+func (rpt *Report) onPage() {
+	rpt.nextPage()
+}
+
 /*
 New returns a pointer to a new Report instance. Options:
   - orientation - Optional. Default value:"P" Values: "P","portrait","L","landscape".
-  - format - Optional. Defaut value: "A4" Values: "A3","A4","A5","letter","legal".
+  - format - Optional. Defaut value: "A4" Values: "A0"-"A7","B4"-"B6","C4"-"C6","DL","letter","legal",
+    "tabloid","ledger","executive","statement","envelope-9","envelope-10","monarch" or a custom
+    "width x height" size in mm, or with unit suffixes (e.g. "80x200mm", "4x6in", "612x792pt").
   - fontFamily - Optional Default: Cabin
-  - fontDir - Optional Default: ""
+  - fontDir - Optional Default: "". If the Family-Regular.ttf file of the fontFamily is missing, the
+    default font family is used, and the FontError function returns the error. Use the AddFontFamily, AddFontFS, AddFontData or AddFontReader
+    functions to register more font families with error checking.
 
 Example:
 
@@ -1566,32 +3850,97 @@ func New(options ...string) (rpt *Report) {
 }
 
 // CreateReport - the report template processing, databind replacement.
+// The font family errors are returned by the FontError function.
 func (rpt *Report) CreateReport() bool {
 	rpt.Pdf.SetProperties(rpt)
 	rpt.setPageStyle(make(IM))
-	rpt.footerHeight = rpt.getFooterHeight()
-	rpt.addPage()
-	for index := 0; index < len(rpt.details); index++ {
-		rpt.createElement("details", rpt.details[index].Item)
+	rpt.footerHeight = rpt.getFooterHeight(rpt.footer)
+	rpt.breakAfter = false
+	rpt.pageLeft, rpt.pageRight = rpt.LeftMargin, rpt.RightMargin
+	rpt.pageOffset = 0
+	if rpt.Continuous {
+		rpt.setContinuousPage()
+	}
+	// a starting section adds its own first page
+	if _, section := rpt.getFirstDetails().(*Section); !section || rpt.Continuous {
+		rpt.addPage()
 	}
+	rpt.createElements("details", rpt.details)
+	rpt.LeftMargin, rpt.RightMargin = rpt.pageLeft, rpt.pageRight
 	return true
 }
 
+// setContinuousPage sets the page height of a continuous report to the height of the first page header,
+// the details and the first page footer. The page width is the width of the report format.
+func (rpt *Report) setContinuousPage() {
+	width, _ := rpt.Pdf.GetPageSize()
+	header, footer := rpt.getPageSections(1)
+	// the header is measured like the footer
+	height := rpt.TopMargin + rpt.getFooterHeight(header) + rpt.getFooterHeight(footer) + rpt.BottomMargin
+	rpt.Pdf.SetXY(rpt.LeftMargin, rpt.TopMargin)
+	height += rpt.getDetailsHeight(rpt.details)
+	rpt.Pdf.SetPageSize(ToString(width, "")+"x"+ToString(height, "")+"pt", "p")
+}
+
+// getDetailsHeight returns the height of the details elements of a continuous report
+func (rpt *Report) getDetailsHeight(elements []PageItem) (height float64) {
+	for index := 0; index < len(elements); index++ {
+		switch v := elements[index].Item.(type) {
+		case *VGap:
+			height += v.Height
+		case *Section:
+			leftMargin, rightMargin := rpt.setSectionMargins(v)
+			height += rpt.getDetailsHeight(v.Elements)
+			rpt.LeftMargin, rpt.RightMargin = leftMargin, rightMargin
+		case *Labels:
+			height += rpt.getLabelsHeight(v)
+		default:
+			height += rpt.getElementHeight(elements[index].Item, false)
+		}
+	}
+	return height
+}
+
+// getFirstDetails returns the first details element
+func (rpt *Report) getFirstDetails() interface{} {
+	if len(rpt.details) > 0 {
+		return rpt.details[0].Item
+	}
+	return nil
+}
+
+// setElementValue sets the parsed value of an element field. The font families must be registered.
+func (rpt *Report) setElementValue(el *PageItem, fieldname string, value interface{}) error {
+	if err := rpt.checkFontFamily(fieldname, value); err != nil {
+		return err
+	}
+	if err := checkOverflow(fieldname, value); err != nil {
+		return err
+	}
+	return el.setPageItem(fieldname, rpt.parseValue(propMap[strings.ToLower(fieldname)], value))
+}
+
 func (rpt *Report) getJSONElements(edata interface{}) (el PageItem, err error) {
 	for eName, eValue := range edata.(IM) {
 		if el, err = rpt.getPageItem(eName); err != nil {
 			return el, err
 		}
 		for ekey, eValueData := range eValue.(IM) {
-			if ekey == "columns" {
+			if ekey == "columns" && eName != "labels" {
 				for colIndex := 0; colIndex < len(eValueData.([]interface{})); colIndex++ {
 					coldata := eValueData.([]interface{})[colIndex]
 					for cName, cValue := range coldata.(IM) {
 						switch cName {
+						case "box":
+							el2, err := rpt.getJSONElements(IM{cName: cValue})
+							if err != nil {
+								return el, err
+							}
+							el.Item.(*Row).Columns = append(el.Item.(*Row).Columns, el2)
 						case "cell", "image", "barcode", "separator", "column":
 							el2, _ := rpt.getPageItem(cName)
 							for ckey, cValueData := range cValue.(IM) {
-								if err := el2.setPageItem(ckey, rpt.parseValue(propMap[strings.ToLower(ckey)], cValueData)); err != nil {
+								if err := rpt.setElementValue(&el2, ckey, cValueData); err != nil {
 									return el, err
 								}
 							}
@@ -1605,8 +3954,55 @@ func (rpt *Report) getJSONElements(edata interface{}) (el PageItem, err error) {
 						}
 					}
 				}
+			} else if ekey == "rows" && eName == "table" {
+				for rowIndex := 0; rowIndex < len(eValueData.([]interface{})); rowIndex++ {
+					el2, err := rpt.getJSONElements(eValueData.([]interface{})[rowIndex])
+					if err != nil {
+						return el, err
+					}
+					if el2.ItemType != "row" {
+						return el, errors.New(invalidErr("Rows", el2.ItemType))
+					}
+					el.Item.(*Table).Rows = append(el.Item.(*Table).Rows, el2)
+				}
+			} else if (ekey == "header" || ekey == "footer") && eName == "section" {
+				elements := make([]PageItem, 0)
+				for elIndex := 0; elIndex < len(eValueData.([]interface{})); elIndex++ {
+					el2, err := rpt.getJSONElements(eValueData.([]interface{})[elIndex])
+					if err != nil {
+						return el, err
+					}
+					if !Contains([]string{"row", "vgap", "hline"}, el2.ItemType) {
+						return el, errors.New(invalidErr("Header", el2.ItemType))
+					}
+					elements = append(elements, el2)
+				}
+				if ekey == "header" {
+					el.Item.(*Section).Header = elements
+				} else {
+					el.Item.(*Section).Footer = elements
+				}
+			} else if ekey == "elements" && (eName == "group" || eName == "box" || eName == "labels" || eName == "section") {
+				for elIndex := 0; elIndex < len(eValueData.([]interface{})); elIndex++ {
+					el2, err := rpt.getJSONElements(eValueData.([]interface{})[elIndex])
+					if err != nil {
+						return el, err
+					}
+					if !Contains(childElements[eName], el2.ItemType) {
+						return el, errors.New(invalidErr("Elements", el2.ItemType))
+					}
+					if eName == "group" {
+						el.Item.(*Group).Elements = append(el.Item.(*Group).Elements, el2)
+					} else if eName == "labels" {
+						el.Item.(*Labels).Elements = append(el.Item.(*Labels).Elements, el2)
+					} else if eName == "section" {
+						el.Item.(*Section).Elements = append(el.Item.(*Section).Elements, el2)
+					} else {
+						el.Item.(*Box).Elements = append(el.Item.(*Box).Elements, el2)
+					}
+				}
 			} else {
-				if err := el.setPageItem(ekey, rpt.parseValue(propMap[strings.ToLower(ekey)], eValueData)); err != nil {
+				if err := rpt.setElementValue(&el, ekey, eValueData); err != nil {
 					return el, err
 				}
 			}
@@ -1626,8 +4022,14 @@ func (rpt *Report) LoadJSONDefinition(jsonString string) error {
 		return err
 	}
 	if report, found := jsonData["report"]; found {
+		// the unit of the length values is set first
+		if unit, found := report.(IM)["unit"]; found {
+			if err := rpt.SetReportValue("unit", unit); err != nil {
+				return err
+			}
+		}
 		for valueKey, valueData := range report.(IM) {
-			if err := rpt.SetReportValue(valueKey, rpt.parseValue(propMap[strings.ToLower(valueKey)], valueData)); err != nil {
+			if err := rpt.SetReportValue(valueKey, valueData); err != nil {
 				return err
 			}
 		}
@@ -1641,6 +4043,20 @@ func (rpt *Report) LoadJSONDefinition(jsonString string) error {
 			rpt.header = append(rpt.header, el)
 		}
 	}
+	for key, section := range map[string]*[]PageItem{
+		"first-header": &rpt.firstHeader, "first-footer": &rpt.firstFooter,
+		"even-header": &rpt.evenHeader, "even-footer": &rpt.evenFooter} {
+		if elements, found := jsonData[key]; found {
+			*section = make([]PageItem, 0)
+			for index := 0; index < len(elements.([]interface{})); index++ {
+				el, err := rpt.getJSONElements(elements.([]interface{})[index])
+				if err != nil {
+					return err
+				}
+				*section = append(*section, el)
+			}
+		}
+	}
 	if details, found := jsonData["details"]; found {
 		for index := 0; index < len(details.([]interface{})); index++ {
 			el, err := rpt.getJSONElements(details.([]interface{})[index])
@@ -1687,10 +4103,154 @@ func (rpt *Report) LoadJSONDefinition(jsonString string) error {
 	return nil
 }
 
+// childElements - the valid element types of the parent element lists
+var childElements = map[string][]string{
+	"header":         {"row", "vgap", "hline"},
+	"footer":         {"row", "vgap", "hline"},
+	"details":        {"row", "vgap", "hline", "html", "datagrid", "table", "group", "labels", "section", "need-space"},
+	"row":            {"cell", "image", "barcode", "separator", "box"},
+	"datagrid":       {"column"},
+	"table":          {"row"},
+	"group":          {"row", "vgap", "hline", "html", "datagrid", "table", "group", "labels", "section", "need-space"},
+	"box":            {"row", "vgap", "hline", "html", "datagrid", "table"},
+	"labels":         {"row", "vgap", "hline", "html", "datagrid", "table"},
+	"section":        {"row", "vgap", "hline", "html", "datagrid", "table", "group", "labels", "need-space"},
+	"section-header": {"row", "vgap", "hline"},
+	"section-footer": {"row", "vgap", "hline"},
+}
+
+// elementLists returns the child element lists of a page element by the parent types
+func elementLists(el *PageItem) map[string]*[]PageItem {
+	switch v := el.Item.(type) {
+	case *Row:
+		return map[string]*[]PageItem{"row": &v.Columns}
+	case *Datagrid:
+		return map[string]*[]PageItem{"datagrid": &v.Columns}
+	case *Table:
+		return map[string]*[]PageItem{"table": &v.Rows}
+	case *Group:
+		return map[string]*[]PageItem{"group": &v.Elements}
+	case *Box:
+		return map[string]*[]PageItem{"box": &v.Elements}
+	case *Labels:
+		return map[string]*[]PageItem{"labels": &v.Elements}
+	case *Section:
+		return map[string]*[]PageItem{"section": &v.Elements, "section-header": &v.Header, "section-footer": &v.Footer}
+	}
+	return nil
+}
+
+// pageSections returns the element lists of the report sections by the parent types
+func (rpt *Report) pageSections() []struct {
+	ptype    string
+	elements *[]PageItem
+} {
+	return []struct {
+		ptype    string
+		elements *[]PageItem
+	}{
+		{"header", &rpt.header}, {"header", &rpt.firstHeader}, {"header", &rpt.evenHeader},
+		{"details", &rpt.details},
+		{"footer", &rpt.footer}, {"footer", &rpt.firstFooter}, {"footer", &rpt.evenFooter},
+	}
+}
+
+// findElement returns the first page element of the report template accepted by the fn function, or nil
+func (rpt *Report) findElement(fn func(el *PageItem) bool) *PageItem {
+	var search func(elements []PageItem) *PageItem
+	search = func(elements []PageItem) *PageItem {
+		for index := range elements {
+			if fn(&elements[index]) {
+				return &elements[index]
+			}
+			for _, list := range elementLists(&elements[index]) {
+				if found := search(*list); found != nil {
+					return found
+				}
+			}
+		}
+		return nil
+	}
+	for _, section := range rpt.pageSections() {
+		if found := search(*section.elements); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// parentType returns the parent type of an element list of the report, or an empty string
+// if the list is not found in the report template
+func (rpt *Report) parentType(parent *[]PageItem) (ptype string) {
+	for _, section := range rpt.pageSections() {
+		if section.elements == parent {
+			return section.ptype
+		}
+	}
+	rpt.findElement(func(el *PageItem) bool {
+		for ltype, list := range elementLists(el) {
+			if list == parent {
+				ptype = ltype
+				return true
+			}
+		}
+		return false
+	})
+	return ptype
+}
+
+// getSection returns the Section of the elements list returned by the AppendElement function, or nil
+func (rpt *Report) getSection(elements *[]PageItem) *Section {
+	if el := rpt.findElement(func(el *PageItem) bool {
+		section, valid := el.Item.(*Section)
+		return valid && &section.Elements == elements
+	}); el != nil {
+		return el.Item.(*Section)
+	}
+	return nil
+}
+
+/*
+SectionHeader returns the header of a section for the AppendElement function. The section parameter is the
+result value of the section appending. The section header replaces all header variants of the report,
+the empty section header removes the report header from the pages of the section. The result is nil if
+the section is not found.
+
+Example:
+
+	section, _ := rpt.AppendElement("details", "section", map[string]interface{}{"orientation": "landscape"})
+	header := rpt.SectionHeader(section)
+	rpt.AppendElement(header, "row")
+*/
+func (rpt *Report) SectionHeader(section *[]PageItem) *[]PageItem {
+	if v := rpt.getSection(section); v != nil {
+		if v.Header == nil {
+			v.Header = []PageItem{}
+		}
+		return &v.Header
+	}
+	return nil
+}
+
+// SectionFooter returns the footer of a section for the AppendElement function. The section footer replaces
+// all footer variants of the report, the empty section footer removes the report footer from the pages of the section.
+func (rpt *Report) SectionFooter(section *[]PageItem) *[]PageItem {
+	if v := rpt.getSection(section); v != nil {
+		if v.Footer == nil {
+			v.Footer = []PageItem{}
+		}
+		return &v.Footer
+	}
+	return nil
+}
+
 /*
 AppendElement - Append an element in the template.
-  - parent - Optional. The parent elemnt. Values: "header","details","footer" or result value (row, datagrid) Default value: "details"
-  - ename - Optional. An Element type: "row", "datagrid", "vgap", "hline", "html", "column", "cell", "image", "separator", "barcode". Default value: "row"
+  - parent - Optional. The parent elemnt. Values: "header","details","footer","first-header","first-footer","even-header","even-footer" or result value (row, datagrid, table, group, box, labels, section),
+    or the result value of the SectionHeader and SectionFooter functions. Default value: "details"
+  - ename - Optional. An Element type: "row", "datagrid", "table", "group", "labels", "section", "need-space", "vgap", "hline", "html", "column", "cell", "image", "separator", "barcode", "box". Default value: "row"
+    The type must be valid under the parent: e.g. the "cell", "image", "barcode", "separator" and "box" elements of a row,
+    the "column" elements of a datagrid or the "row" elements of a table.
   - values - Optional. Element attributes
 
 Example:
@@ -1711,7 +4271,19 @@ func (rpt *Report) AppendElement(options ...interface{}) (*[]PageItem, error) {
 				parent = &rpt.header
 				if len(options) > 1 {
 					ename := ToString(options[1], "")
-					if Contains([]string{"row", "vgap", "hline"}, ename) {
+					if Contains(childElements["header"], ename) {
+						el, _ = rpt.getPageItem(ename)
+					} else {
+						return nil, errors.New(invalidErr("Header", ename))
+					}
+				}
+			case "first-header", "first-footer", "even-header", "even-footer":
+				parent = map[string]*[]PageItem{
+					"first-header": &rpt.firstHeader, "first-footer": &rpt.firstFooter,
+					"even-header": &rpt.evenHeader, "even-footer": &rpt.evenFooter}[options[0].(string)]
+				if len(options) > 1 {
+					ename := ToString(options[1], "")
+					if Contains(childElements["header"], ename) {
 						el, _ = rpt.getPageItem(ename)
 					} else {
 						return nil, errors.New(invalidErr("Header", ename))
@@ -1721,7 +4293,7 @@ func (rpt *Report) AppendElement(options ...interface{}) (*[]PageItem, error) {
 				parent = &rpt.details
 				if len(options) > 1 {
 					ename := ToString(options[1], "")
-					if Contains([]string{"row", "vgap", "hline", "html", "datagrid"}, ename) {
+					if Contains(childElements["details"], ename) {
 						el, _ = rpt.getPageItem(ename)
 					} else {
 						return nil, errors.New(invalidErr("Details", ename))
@@ -1731,7 +4303,7 @@ func (rpt *Report) AppendElement(options ...interface{}) (*[]PageItem, error) {
 				parent = &rpt.footer
 				if len(options) > 1 {
 					ename := ToString(options[1], "")
-					if Contains([]string{"row", "vgap", "hline"}, ename) {
+					if Contains(childElements["footer"], ename) {
 						el, _ = rpt.getPageItem(ename)
 					} else {
 						return nil, errors.New(invalidErr("Footer", ename))
@@ -1740,16 +4312,16 @@ func (rpt *Report) AppendElement(options ...interface{}) (*[]PageItem, error) {
 			}
 		case *[]PageItem:
 			parent = options[0].(*[]PageItem)
+			ptype, ename := rpt.parentType(parent), "row"
 			if len(options) > 1 {
-				ename := ToString(options[1], "")
-				if Contains([]string{"cell", "image", "barcode", "separator", "column"}, ename) {
-					el, _ = rpt.getPageItem(ename)
-				} else {
-					return nil, errors.New(invalidErr("columns", ename))
-				}
+				ename = ToString(options[1], "")
+			}
+			if !Contains(childElements[ptype], ename) {
+				return nil, errors.New(invalidErr(ToString(ptype, "parent"), ename))
 			}
+			el, _ = rpt.getPageItem(ename)
 		default:
-			return nil, errors.New("valid parent values: 'header','details','footer' (string) or Columns of Row and Datagrid (*[]PageItem)")
+			return nil, errors.New("valid parent values: 'header','details','footer','first-header','first-footer','even-header','even-footer' (string) or Columns of Row and Datagrid, or Elements of Group (*[]PageItem)")
 		}
 	}
 
@@ -1757,7 +4329,7 @@ func (rpt *Report) AppendElement(options ...interface{}) (*[]PageItem, error) {
 		switch options[2].(type) {
 		case IM:
 			for key, value := range options[2].(IM) {
-				if err := el.setPageItem(key, rpt.parseValue(propMap[strings.ToLower(key)], value)); err != nil {
+				if err := rpt.setElementValue(&el, key, value); err != nil {
 					return nil, err
 				}
 			}
@@ -1772,6 +4344,16 @@ func (rpt *Report) AppendElement(options ...interface{}) (*[]PageItem, error) {
 		return &el.Item.(*Row).Columns, nil
 	} else if el.ItemType == "datagrid" {
 		return &el.Item.(*Datagrid).Columns, nil
+	} else if el.ItemType == "group" {
+		return &el.Item.(*Group).Elements, nil
+	} else if el.ItemType == "box" {
+		return &el.Item.(*Box).Elements, nil
+	} else if el.ItemType == "table" {
+		return &el.Item.(*Table).Rows, nil
+	} else if el.ItemType == "labels" {
+		return &el.Item.(*Labels).Elements, nil
+	} else if el.ItemType == "section" {
+		return &el.Item.(*Section).Elements, nil
 	}
 	return parent, nil
 }
@@ -1,9 +1,13 @@
 package report
 
 import (
+	"fmt"
 	"image/color"
+	"math"
 	"os"
 	"path"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -461,6 +465,67 @@ func TestPageItem_setPageItem(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "cell_Padding",
+			fields: fields{
+				ItemType: "cell",
+				Item:     &Cell{},
+			},
+			args: args{
+				fieldname: "padding-left",
+				value:     "2.83465",
+			},
+			wantErr: false,
+		},
+		{
+			name: "html_LineHeight",
+			fields: fields{
+				ItemType: "html",
+				Item:     &HTML{},
+			},
+			args: args{
+				fieldname: "line-height",
+				value:     "14.17",
+			},
+			wantErr: false,
+		},
+		{
+			name: "column_FontFamily",
+			fields: fields{
+				ItemType: "column",
+				Item:     &Column{},
+			},
+			args: args{
+				fieldname: "font-family",
+				value:     "Cabin",
+			},
+			wantErr: false,
+		},
+		{
+			name: "cell_Overflow",
+			fields: fields{
+				ItemType: "cell",
+				Item:     &Cell{},
+			},
+			args: args{
+				fieldname: "overflow",
+				value:     "ellipsis",
+			},
+			wantErr: false,
+		},
+
+		{
+			name: "group_KeepTogether",
+			fields: fields{
+				ItemType: "group",
+				Item:     &Group{},
+			},
+			args: args{
+				fieldname: "keep-together",
+				value:     true,
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -713,6 +778,33 @@ func TestReport_SetReportValue(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:   "Padding",
+			fields: fields{},
+			args: args{
+				fieldname: "padding",
+				value:     1,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "LineHeight",
+			fields: fields{},
+			args: args{
+				fieldname: "line-height",
+				value:     5,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Direction",
+			fields: fields{},
+			args: args{
+				fieldname: "direction",
+				value:     "rtl",
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -852,6 +944,71 @@ func TestReport_setHTMLValue(t *testing.T) {
 	}
 }
 
+func TestReport_writeHTML(t *testing.T) {
+	type args struct {
+		htmlStr  string
+		alignStr string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		breaks int
+	}{
+		{
+			name: "left",
+			args: args{
+				htmlStr:  "<p>Lorem ipsum <b>dolor</b> sit amet.</p>",
+				alignStr: "L",
+			},
+			breaks: 1,
+		},
+		{
+			name: "center",
+			args: args{
+				htmlStr:  "Lorem ipsum <i>dolor</i> sit amet.",
+				alignStr: "C",
+			},
+			breaks: 0,
+		},
+		{
+			name: "right",
+			args: args{
+				htmlStr:  "Lorem ipsum dolor<br>sit amet.",
+				alignStr: "R",
+			},
+			breaks: 1,
+		},
+		{
+			name: "justify",
+			args: args{
+				htmlStr:  strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 10),
+				alignStr: "J",
+			},
+			breaks: 4,
+		},
+		{
+			name: "long_word",
+			args: args{
+				htmlStr:  "Lorem " + strings.Repeat("ipsum", 60),
+				alignStr: "J",
+			},
+			breaks: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpt := New("p", "A4")
+			rpt.CreateReport()
+			lineHt := rpt.Pdf.GetFontSize()
+			startY := rpt.Pdf.GetY()
+			rpt.writeHTML(lineHt, tt.args.htmlStr, IM{"align": tt.args.alignStr})
+			if got := int((rpt.Pdf.GetY()-startY)/lineHt + 0.5); got != tt.breaks {
+				t.Errorf("Report.writeHTML() breaks = %v, want %v", got, tt.breaks)
+			}
+		})
+	}
+}
+
 func TestReport_setValue(t *testing.T) {
 	type fields struct {
 		Pdf             Generator
@@ -1077,6 +1234,41 @@ func TestReport_getCellHeight(t *testing.T) {
 			},
 			want: float64(16.4),
 		},
+		{
+			name: "padding",
+			fields: fields{
+				Pdf:        rpt.Pdf,
+				FontFamily: rpt.FontFamily,
+			},
+			args: args{
+				text: "",
+				options: IM{
+					"fontStyle":  "",
+					"fontSize":   float64(10),
+					"padding":    "0",
+					"paddingTop": "2",
+				},
+			},
+			want: float64(12),
+		},
+		{
+			name: "line_height",
+			fields: fields{
+				Pdf:        rpt.Pdf,
+				FontFamily: rpt.FontFamily,
+			},
+			args: args{
+				text:  "Lorem ipsum dolor sit amet",
+				width: float64(40),
+				options: IM{
+					"fontStyle":  "",
+					"fontSize":   float64(10),
+					"padding":    "1",
+					"lineHeight": "20",
+				},
+			},
+			want: float64(72),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1776,6 +1968,87 @@ func TestReport_createRow(t *testing.T) {
 	}
 }
 
+func TestReport_getRowVAlign(t *testing.T) {
+	rpt := New("p", "A4")
+	rpt.CreateReport()
+	rowElement := &Row{
+		VAlign: "M",
+		Columns: []PageItem{
+			{ItemType: "cell", Item: &Cell{Value: "Label", Width: "100", Align: "L", FontSize: 12}},
+			{ItemType: "cell", Item: &Cell{
+				Value: strings.Repeat("Lorem ipsum dolor sit amet. ", 20), Align: "J", Multiline: true, FontSize: 9}},
+		},
+	}
+	startX, startY := rpt.Pdf.GetX(), rpt.Pdf.GetY()
+	height := rpt.createRow("details", rowElement, true)
+	rpt.Pdf.SetXY(startX, startY)
+	rowHeight, baseline, _ := rpt.getRowVAlign(rowElement, height)
+	if rpt.Pdf.GetY() != startY {
+		t.Errorf("Report.getRowVAlign() position = %v, want %v", rpt.Pdf.GetY(), startY)
+	}
+	if baseline != _padding/2+12 {
+		t.Errorf("Report.getRowVAlign() baseline = %v, want %v", baseline, _padding/2+12)
+	}
+	if got := rpt.createRow("details", rowElement, false); got != rowHeight {
+		t.Errorf("Report.createRow() = %v, want %v", got, rowHeight)
+	}
+	if !rowAligned(rowElement) || rowAligned(&Row{Columns: rowElement.Columns[:1]}) {
+		t.Errorf("rowAligned() the aligned rows are not detected")
+	}
+	// the baseline aligned barcode stands on the baseline of the cells
+	barcodeRow := &Row{
+		VAlign: "A",
+		Columns: []PageItem{
+			{ItemType: "cell", Item: &Cell{Value: "Code", Width: "100", FontSize: 12}},
+			{ItemType: "barcode", Item: &Barcode{CodeType: "code39", Value: "1234", Height: 40}},
+		},
+	}
+	height = rpt.createRow("details", barcodeRow, true)
+	rowHeight, baseline, heights := rpt.getRowVAlign(barcodeRow, height)
+	if heights[1] != 40 {
+		t.Errorf("Report.getRowVAlign() barcode height = %v, want 40", heights[1])
+	}
+	if baseline != 40 || rowHeight != 40+_padding/2 {
+		t.Errorf("Report.getRowVAlign() = %v, %v, want %v, %v", rowHeight, baseline, 40+_padding/2, 40)
+	}
+}
+
+func TestReport_createRow_valign(t *testing.T) {
+	rpt := New("p", "A4")
+	if err := rpt.LoadJSONDefinition(`{"details": [{"row": {"hgap": 2, "valign": "middle", "columns": [
+		{"cell": {"value": "Label", "width": "30"}},
+		{"cell": {"value": "` + strings.Repeat("Lorem ipsum dolor sit amet. ", 20) + `", "multiline": true}}]}}]}`); err != nil {
+		t.Fatal(err)
+	}
+	recorder := &cellRecorder{Generator: rpt.Pdf}
+	rpt.Pdf = recorder
+	rpt.CreateReport()
+	label := recorder.cells[0]
+	if label["text"] != "Label" || label["valign"] != "M" || ToFloat(label["h"], 0) <= rpt.FontSize*2 {
+		t.Errorf("Report.createRow() middle aligned label cell = %v", label)
+	}
+}
+
+func TestGetVAlignOffset(t *testing.T) {
+	tests := []struct {
+		name   string
+		valign string
+		want   float64
+	}{
+		{name: "top", valign: "T", want: 0},
+		{name: "middle", valign: "M", want: 5},
+		{name: "bottom", valign: "B", want: 10},
+		{name: "baseline", valign: "A", want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getVAlignOffset(tt.valign, 30, 22, 20); got != tt.want {
+				t.Errorf("getVAlignOffset() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestReport_createLine(t *testing.T) {
 	type fields struct {
 		Pdf             Generator
@@ -2053,6 +2326,216 @@ func TestReport_setFont(t *testing.T) {
 	}
 }
 
+func TestReport_AddFontFamily(t *testing.T) {
+	rpt := New("p", "A4")
+	tests := []struct {
+		name    string
+		family  string
+		fontDir string
+		wantErr bool
+	}{
+		{
+			name:    "missing_dir",
+			family:  "Roboto",
+			fontDir: "../../data/fonts",
+			wantErr: true,
+		},
+		{
+			name:    "missing_files",
+			family:  "Roboto",
+			fontDir: "fonts",
+			wantErr: true,
+		},
+		{
+			name:    "partial_family",
+			family:  "Cabin",
+			fontDir: "fonts",
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rpt.AddFontFamily(tt.family, tt.fontDir); (err != nil) != tt.wantErr {
+				t.Errorf("Report.AddFontFamily() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReport_AddFontFS(t *testing.T) {
+	rpt := New("p", "A4")
+	tests := []struct {
+		name    string
+		family  string
+		style   string
+		file    string
+		wantErr bool
+	}{
+		{
+			name:    "bold",
+			family:  "Serif",
+			style:   "bold",
+			file:    "fonts/Cabin-Bold.ttf",
+			wantErr: false,
+		},
+		{
+			name:    "missing_file",
+			family:  "Serif",
+			style:   "I",
+			file:    "fonts/Serif-Italic.ttf",
+			wantErr: true,
+		},
+		{
+			name:    "missing_family",
+			family:  "",
+			style:   "I",
+			file:    "fonts/Cabin-Italic.ttf",
+			wantErr: true,
+		},
+		{
+			name:    "invalid_style",
+			family:  "Serif",
+			style:   "underline",
+			file:    "fonts/Cabin-Italic.ttf",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rpt.AddFontFS(Fonts, tt.family, tt.style, tt.file); (err != nil) != tt.wantErr {
+				t.Errorf("Report.AddFontFS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReport_AddFontData(t *testing.T) {
+	rpt := New("p", "A4")
+	data, _ := Fonts.ReadFile("fonts/Cabin-Regular.ttf")
+	if err := rpt.AddFontData("Mono", "", data); err != nil {
+		t.Errorf("Report.AddFontData() error = %v", err)
+	}
+	if err := rpt.AddFontData("Mono", "B", []byte("font")); err == nil {
+		t.Error("Report.AddFontData() invalid font data error = nil")
+	}
+	if err := rpt.AddFontFile("Mono", "I", "fonts/Mono-Italic.ttf"); err == nil {
+		t.Error("Report.AddFontFile() missing file error = nil")
+	}
+}
+
+func TestReport_getFontStyle(t *testing.T) {
+	rpt := New("p", "A4")
+	rpt.addFontStyle("Mono", "")
+	rpt.addFontStyle("Mono", "B")
+	tests := []struct {
+		name   string
+		family string
+		style  string
+		want   string
+	}{
+		{name: "regular", family: "Mono", style: "", want: ""},
+		{name: "bold", family: "Mono", style: "B", want: "B"},
+		{name: "italic_fallback", family: "Mono", style: "I", want: ""},
+		{name: "bolditalic_fallback", family: "Mono", style: "BIU", want: "BU"},
+		{name: "all_styles", family: "Cabin", style: "IB", want: "BI"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rpt.getFontStyle(tt.family, tt.style); got != tt.want {
+				t.Errorf("Report.getFontStyle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReport_SetFallbackFonts(t *testing.T) {
+	rpt := New("p", "A4")
+	_ = rpt.AddFontFS(Fonts, "Serif", "B", "fonts/Cabin-Bold.ttf")
+	tests := []struct {
+		name     string
+		families []string
+		style    string
+		want     []fontFace
+		wantErr  bool
+	}{
+		{
+			name:     "registered",
+			families: []string{"Serif"},
+			style:    "I",
+			want:     []fontFace{{family: "Serif", style: "B"}},
+			wantErr:  false,
+		},
+		{
+			name:     "unregistered",
+			families: []string{"Serif", "Symbol"},
+			style:    "",
+			want:     []fontFace{{family: "Serif", style: "B"}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rpt.SetFallbackFonts(tt.families...); (err != nil) != tt.wantErr {
+				t.Errorf("Report.SetFallbackFonts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := rpt.getFallbackFonts(tt.style); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Report.getFallbackFonts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenGoPDF_textRuns(t *testing.T) {
+	rpt := New("p", "A4")
+	_ = rpt.AddFontFS(Fonts, "Serif", "", "fonts/Cabin-Regular.ttf")
+	rpt.FallbackFonts = "Serif"
+	rpt.Pdf.AddPage()
+	rpt.Pdf.SetFont("Cabin", "", 10)
+	gen := rpt.Pdf.(*genGoPDF)
+	if runs := gen.textRuns("Cabin 1"); len(runs) != 1 {
+		t.Errorf("genGoPDF.textRuns() = %v, want 1 run", runs)
+	}
+	if runs := gen.textRuns("Cabin \u4e2d"); len(runs) != 1 || runs[0].face.family != "Cabin" {
+		t.Errorf("genGoPDF.textRuns() = %v, want 1 Cabin run", runs)
+	}
+	if width := gen.GetTextWidth("Cabin"); width <= 0 {
+		t.Errorf("genGoPDF.GetTextWidth() = %v", width)
+	}
+}
+
+func TestReport_getFontFamily(t *testing.T) {
+	rpt := New("p", "A4")
+	rpt.addFontStyle("Roboto", "B")
+	tests := []struct {
+		name   string
+		family string
+		want   string
+	}{
+		{
+			name:   "registered",
+			family: "Roboto",
+			want:   "Roboto",
+		},
+		{
+			name:   "missing",
+			family: "Arial",
+			want:   "Cabin",
+		},
+		{
+			name:   "empty",
+			family: "",
+			want:   "Cabin",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rpt.getFontFamily(tt.family); got != tt.want {
+				t.Errorf("Report.getFontFamily() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestReport_SetData(t *testing.T) {
 	type fields struct {
 		Pdf             Generator
@@ -2280,12 +2763,23 @@ func TestReport_AppendElement(t *testing.T) {
 			},
 			wantErr: true,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			rpt := &Report{
-				Pdf:             tt.fields.Pdf,
-				orientation:     tt.fields.orientation,
+
+		{
+			name:   "group",
+			fields: fields{},
+			args: args{
+				options: []interface{}{
+					"details", "group", IM{"keep-together": true},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpt := &Report{
+				Pdf:             tt.fields.Pdf,
+				orientation:     tt.fields.orientation,
 				format:          tt.fields.format,
 				fontDir:         tt.fields.fontDir,
 				xmlHeader:       tt.fields.xmlHeader,
@@ -2322,6 +2816,51 @@ func TestReport_AppendElement(t *testing.T) {
 	}
 }
 
+func TestReport_AppendElement_parent(t *testing.T) {
+	rpt := New("p", "A4")
+	grid, _ := rpt.AppendElement("details", "datagrid")
+	group, _ := rpt.AppendElement("details", "group")
+	box, _ := rpt.AppendElement(group, "row")
+	box, _ = rpt.AppendElement(box, "box")
+	tests := []struct {
+		name    string
+		parent  *[]PageItem
+		ename   string
+		wantErr bool
+	}{
+		{name: "grid_column", parent: grid, ename: "column", wantErr: false},
+		{name: "grid_cell", parent: grid, ename: "cell", wantErr: true},
+		{name: "group_html", parent: group, ename: "html", wantErr: false},
+		{name: "group_cell", parent: group, ename: "cell", wantErr: true},
+		{name: "box_row", parent: box, ename: "row", wantErr: false},
+		{name: "box_group", parent: box, ename: "group", wantErr: true},
+		{name: "details", parent: &rpt.details, ename: "labels", wantErr: false},
+		{name: "unknown_parent", parent: &[]PageItem{}, ename: "row", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := rpt.AppendElement(tt.parent, tt.ename); (err != nil) != tt.wantErr {
+				t.Errorf("Report.AppendElement() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReport_getElementHeight_datagrid(t *testing.T) {
+	rpt := New("p", "A4")
+	rpt.Pdf.AddPage()
+	rows := []SM{{"text": "1"}, {"text": "2"}, {"text": "3"}}
+	rpt.SetData("items", rows)
+	grid := &Datagrid{Databind: "items", MinRows: 1, Columns: []PageItem{{ItemType: "column", Item: &Column{Fieldname: "text"}}}}
+	first, height := rpt.getElementHeight(grid, true), rpt.getElementHeight(grid, false)
+	if first <= 0 || first >= height {
+		t.Errorf("Report.getElementHeight() = %v, want less than %v", first, height)
+	}
+	if data := rpt.data["items"].([]SM); len(data) != len(rows) {
+		t.Errorf("Report.getElementHeight() data rows = %v, want %v", len(data), len(rows))
+	}
+}
+
 func TestReport_getJSONElements(t *testing.T) {
 	type fields struct {
 		Pdf             Generator
@@ -2599,3 +3138,1415 @@ func TestReport_LoadJSONDefinition(t *testing.T) {
 		})
 	}
 }
+
+func TestBidiText(t *testing.T) {
+	tests := []struct {
+		name      string
+		txtStr    string
+		direction string
+		want      string
+	}{
+		{name: "ltr", txtStr: "Invoice 123", direction: "", want: "Invoice 123"},
+		{name: "mixed_ltr", txtStr: "abc שלום 123 def", direction: "", want: "abc 123 םולש def"},
+		{name: "auto_rtl", txtStr: "שלום abc 12", direction: "", want: "abc 12 םולש"},
+		{name: "rtl_mirror", txtStr: "(שלום)", direction: "rtl", want: "(םולש)"},
+		{name: "rtl_latin", txtStr: "Hello.", direction: "rtl", want: ".Hello"},
+		{name: "rtl_number", txtStr: "מחיר 100", direction: "", want: "100 ריחמ"},
+		{name: "rtl_separators", txtStr: "מחיר 1,234.50", direction: "", want: "1,234.50 ריחמ"},
+		{name: "rtl_terminator", txtStr: "מחיר 12.5% $30", direction: "", want: "$30 12.5% ריחמ"},
+		{name: "arabic_number", txtStr: "سعر 1,234.50", direction: "", want: "1,234.50 رعس"},
+		{name: "rtl_brackets", txtStr: "שלום (abc) 12", direction: "", want: "12 (abc) םולש"},
+		{name: "ltr_brackets", txtStr: "abc (שלום) def", direction: "", want: "abc (םולש) def"},
+		{name: "rtl_trailing_space", txtStr: "שלום. ", direction: "", want: " .םולש"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bidiText(tt.txtStr, tt.direction); got != tt.want {
+				t.Errorf("bidiText() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBidiWords(t *testing.T) {
+	words := []htmlWord{{text: "שלום"}, {text: "abc", space: true}, {text: "def", space: true}, {text: "עולם,", space: true}}
+	want := []string{",םלוע", "abc", "def", "םולש"}
+	got := bidiWords(words, "rtl")
+	for index, word := range got {
+		if word.text != want[index] {
+			t.Errorf("bidiWords() = %v, want %v", got, want)
+			break
+		}
+	}
+	if got[0].space || !got[1].space {
+		t.Errorf("bidiWords() spaces = %v", got)
+	}
+	if got := bidiWords(words[1:3], ""); got[0].text != "abc" {
+		t.Errorf("bidiWords() = %v", got)
+	}
+}
+
+func TestDefaultAlign(t *testing.T) {
+	if got := defaultAlign("", "rtl"); got != "R" {
+		t.Errorf("defaultAlign() = %v, want R", got)
+	}
+	if got := defaultAlign("", ""); got != "L" {
+		t.Errorf("defaultAlign() = %v, want L", got)
+	}
+	if got := defaultAlign("L", "rtl"); got != "L" {
+		t.Errorf("defaultAlign() = %v, want L", got)
+	}
+}
+
+func TestTextDirection(t *testing.T) {
+	if got := textDirection("12 שלום abc", ""); got != "rtl" {
+		t.Errorf("textDirection() = %v, want rtl", got)
+	}
+	if got := textDirection("שלום", "ltr"); got != "ltr" {
+		t.Errorf("textDirection() = %v, want ltr", got)
+	}
+	words := []htmlWord{{text: "abc"}, {brk: true}, {text: "שלום"}}
+	if got := paragraphDirection(words, ""); got != "ltr" {
+		t.Errorf("paragraphDirection() = %v, want ltr", got)
+	}
+	if got := paragraphDirection(words[2:], ""); got != "rtl" {
+		t.Errorf("paragraphDirection() = %v, want rtl", got)
+	}
+}
+
+func TestArabicShaper_Shape(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "isolated", text: "ب", want: "ﺏ"},
+		{name: "initial_final", text: "بب", want: "ﺑﺐ"},
+		{name: "medial", text: "ببب", want: "ﺑﺒﺐ"},
+		{name: "right_joining", text: "دب", want: "ﺩﺏ"},
+		{name: "lam_alef", text: "سلام", want: "ﺳﻼﻡ"},
+		{name: "harakat", text: "بَب", want: "ﺑَﺐ"},
+		{name: "latin", text: "abc", want: "abc"},
+		{name: "shaped", text: "ﺑﺐ", want: "ﺑﺐ"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (ArabicShaper{}).Shape(tt.text); got != tt.want {
+				t.Errorf("ArabicShaper.Shape() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReport_shapeText(t *testing.T) {
+	rpt := New("p", "A4")
+	if got := rpt.shapeText("بب"); got != "بب" {
+		t.Errorf("Report.shapeText() = %q, want unshaped text", got)
+	}
+	rpt.SetShaper(ArabicShaper{})
+	if got := rpt.shapeText("بب"); got != "ﺑﺐ" {
+		t.Errorf("Report.shapeText() = %q, want %q", got, "ﺑﺐ")
+	}
+	if got := rpt.Pdf.GetTextWidth("abc"); got <= 0 {
+		t.Errorf("GetTextWidth() = %v", got)
+	}
+}
+
+func TestIndicShaper_Shape(t *testing.T) {
+	shaper := IndicShaper{Ligatures: map[string]rune{"क्ष": '\uE000'}}
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "pre_base", text: "किताब", want: "िकताब"},
+		{name: "nukta", text: "क़िला", want: "िक़ला"},
+		{name: "virama", text: "स्थिति", want: "िस्थित"},
+		{name: "cluster", text: "स्ति", want: "िस्त"},
+		{name: "three_consonants", text: "स्त्रि", want: "िस्त्र"},
+		{name: "ligature_cluster", text: "क्ष्मि", want: "ि\uE000्म"},
+		{name: "ligature", text: "क्षि", want: "ि\uE000"},
+		{name: "two_part", text: "কো", want: "েকা"},
+		{name: "latin", text: "abc", want: "abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shaper.Shape(tt.text); got != tt.want {
+				t.Errorf("IndicShaper.Shape() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if got := (Shapers{ArabicShaper{}, IndicShaper{}}).Shape("بب कि"); got != "ﺑﺐ िक" {
+		t.Errorf("Shapers.Shape() = %q", got)
+	}
+}
+
+// countShaper - a test Shaper counting the shaped texts
+type countShaper struct {
+	count *int
+}
+
+func (shaper countShaper) Shape(text string) string {
+	*shaper.count++
+	return text
+}
+
+func TestGenGoPDF_shapeOnce(t *testing.T) {
+	rpt := New("p", "A4")
+	count := 0
+	rpt.SetShaper(countShaper{count: &count})
+	rpt.Pdf.AddPage()
+	for _, align := range []string{"L", "C", "R", "J"} {
+		count = 0
+		rpt.Pdf.Cell(IM{"w": float64(100), "h": float64(20), "txtStr": "Lorem ipsum", "borderStr": "",
+			"alignStr": align, "fill": false, "ln": false})
+		if count != 1 {
+			t.Errorf("genGoPDF.Cell() align %s shaped %d times, want 1", align, count)
+		}
+	}
+}
+
+func TestReport_parseTextDecoration(t *testing.T) {
+	rpt := New("p", "A4")
+	tests := []struct {
+		name  string
+		vname string
+		value string
+		want  string
+	}{
+		{name: "underline_style", vname: "FontStyle", value: "underline", want: "U"},
+		{name: "strike_style", vname: "FontStyle", value: "strikethrough", want: "S"},
+		{name: "bold_underline_style", vname: "FontStyle", value: "BU", want: "BU"},
+		{name: "bold_style", vname: "FontStyle", value: "bold", want: "B"},
+		{name: "invalid_style", vname: "FontStyle", value: "BX", want: ""},
+		{name: "decoration", vname: "TextDecoration", value: "underline line-through", want: "US"},
+		{name: "decoration_none", vname: "TextDecoration", value: "none", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rpt.parseValue(tt.vname, tt.value); got != tt.want {
+				t.Errorf("Report.parseValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReport_getHTMLWords_decoration(t *testing.T) {
+	rpt := New("p", "A4")
+	rpt.Pdf.AddPage()
+	words := rpt.getHTMLWords("<u>new</u> <del>old</del> <b><s>price</s></b>", "", 10)
+	want := []string{"U", "S", "BS"}
+	for index, word := range words {
+		if word.style != want[index] {
+			t.Errorf("Report.getHTMLWords() style = %v, want %v", word.style, want[index])
+		}
+	}
+}
+
+// decorateRecorder - a test Generator recording the decoration widths and font styles
+type decorateRecorder struct {
+	Generator
+	widths []float64
+	styles []string
+	style  string
+}
+
+func (gen *decorateRecorder) SetFont(familyStr, styleStr string, size float64) {
+	gen.style = styleStr
+	gen.Generator.SetFont(familyStr, styleStr, size)
+}
+
+func (gen *decorateRecorder) Decorate(x, y, width float64) {
+	gen.widths = append(gen.widths, width)
+	gen.styles = append(gen.styles, gen.style)
+	gen.Generator.Decorate(x, y, width)
+}
+
+// textRecorder - a test Generator recording the printed text lines
+type textRecorder struct {
+	Generator
+	lines []IM
+}
+
+func (gen *textRecorder) TextLine(x, y float64, txtStr string) {
+	gen.lines = append(gen.lines, IM{"x": x, "y": y, "text": txtStr})
+	gen.Generator.TextLine(x, y, txtStr)
+}
+
+func TestReport_justify(t *testing.T) {
+	rpt := New("p", "A4")
+	rpt.CreateReport()
+	recorder := &textRecorder{Generator: rpt.Pdf}
+	rpt.Pdf = recorder
+	right := float64(300)
+	rpt.writeHTML(rpt.Pdf.GetFontSize(), strings.Repeat("Lorem ipsum dolor sit amet. ", 10), IM{"align": "J", "right": right})
+	// the words of the first line
+	line := make([]IM, 0)
+	for _, word := range recorder.lines {
+		if word["y"] == recorder.lines[0]["y"] {
+			line = append(line, word)
+		}
+	}
+	if len(line) < 3 {
+		t.Fatalf("Report.writeHTML() first line = %v", line)
+	}
+	gap := func(index int) float64 {
+		return line[index]["x"].(float64) - line[index-1]["x"].(float64) - rpt.Pdf.GetTextWidth(line[index-1]["text"].(string))
+	}
+	for index := 2; index < len(line); index++ {
+		if math.Abs(gap(index)-gap(1)) > 1e-6 {
+			t.Errorf("Report.writeHTML() word gap = %v, want %v", gap(index), gap(1))
+		}
+	}
+	last := line[len(line)-1]
+	if end := last["x"].(float64) + rpt.Pdf.GetTextWidth(last["text"].(string)); math.Abs(end-right) > 1e-6 {
+		t.Errorf("Report.writeHTML() line end = %v, want %v", end, right)
+	}
+	if gap(1) <= rpt.Pdf.GetTextWidth(" ") {
+		t.Errorf("Report.writeHTML() word gap = %v, want wider than a space", gap(1))
+	}
+
+	gen := recorder.Generator.(*genGoPDF)
+	gen.pdf.SetXY(50, 100)
+	gen.justifyCell(50, 100, IM{"w": float64(200), "h": float64(12), "txtStr": "Lorem ipsum dolor", "paddingRight": float64(4)})
+	if x := gen.pdf.GetX(); math.Abs(x-246) > 1e-6 {
+		t.Errorf("genGoPDF.justifyCell() line end = %v, want 246", x)
+	}
+}
+
+func TestReport_writeHTML_decoration(t *testing.T) {
+	for _, align := range []string{"L", "J"} {
+		rpt := New("p", "A4")
+		rpt.Pdf.AddPage()
+		recorder := &decorateRecorder{Generator: rpt.Pdf}
+		rpt.Pdf = recorder
+		rpt.Pdf.SetXY(rpt.LeftMargin, 100)
+		rpt.writeHTML(10, "<u>one two</u> three <u>four</u>", IM{"align": align, "right": float64(200)})
+		if len(recorder.widths) != 2 {
+			t.Fatalf("Report.writeHTML() align %s decorations = %v, want 2 lines", align, recorder.widths)
+		}
+		if recorder.widths[0] <= rpt.Pdf.GetTextWidth("one")+rpt.Pdf.GetTextWidth("two") {
+			t.Errorf("Report.writeHTML() align %s decoration width = %v, want the words and the gap", align, recorder.widths[0])
+		}
+	}
+}
+
+func TestReport_writeHTML_decorationRuns(t *testing.T) {
+	rpt := New("p", "A4")
+	rpt.Pdf.AddPage()
+	recorder := &decorateRecorder{Generator: rpt.Pdf}
+	rpt.Pdf = recorder
+	rpt.Pdf.SetXY(rpt.LeftMargin, 100)
+	rpt.writeHTML(10, "<u>one two</u> three <s>four five</s>", IM{})
+	if len(recorder.widths) != 2 {
+		t.Fatalf("Report.writeHTML() decorations = %v, want 2 runs", recorder.widths)
+	}
+	want := []string{"U", "S"}
+	for index, text := range []string{"one two", "four five"} {
+		if recorder.styles[index] != want[index] {
+			t.Errorf("Report.writeHTML() run %d style = %v, want %v", index, recorder.styles[index], want[index])
+		}
+		rpt.setPdfFont("", want[index], rpt.Pdf.GetFontSize())
+		if width := rpt.Pdf.GetTextWidth(text); math.Abs(recorder.widths[index]-width) > 1e-6 {
+			t.Errorf("Report.writeHTML() run %d width = %v, want %v", index, recorder.widths[index], width)
+		}
+	}
+}
+
+func TestReport_datagridDecoration(t *testing.T) {
+	rpt := New("p", "A4")
+	grid, _ := rpt.getPageItem("datagrid")
+	column, _ := rpt.getPageItem("column")
+	if err := rpt.setElementValue(&grid, "text-decoration", "underline"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rpt.setElementValue(&column, "text-decoration", "line-through"); err != nil {
+		t.Fatal(err)
+	}
+	if grid.Item.(*Datagrid).TextDecoration != "U" || column.Item.(*Column).TextDecoration != "S" {
+		t.Errorf("Report.setElementValue() = %v, %v, want U, S",
+			grid.Item.(*Datagrid).TextDecoration, column.Item.(*Column).TextDecoration)
+	}
+}
+
+func TestSplitStyle(t *testing.T) {
+	fontStyle, decoration := splitStyle("BUIS")
+	if fontStyle != "BI" || decoration != "US" {
+		t.Errorf("splitStyle() = %v, %v, want BI, US", fontStyle, decoration)
+	}
+}
+
+func TestReport_fitText(t *testing.T) {
+	rpt := New("p", "A4")
+	rpt.Pdf.AddPage()
+	text := "Lorem ipsum dolor sit amet"
+	tests := []struct {
+		name     string
+		overflow string
+		width    float64
+		options  IM
+		want     string
+	}{
+		{name: "fit", overflow: "clip", width: 500, options: IM{}, want: text},
+		{name: "clip", overflow: "clip", width: 60, options: IM{}, want: "Lorem ipsum "},
+		{name: "ellipsis", overflow: "ellipsis", width: 60, options: IM{}, want: "Lorem ipsu…"},
+		{name: "shrink", overflow: "shrink", width: 100, options: IM{"fontStyle": ""}, want: text},
+		{name: "shrink_min", overflow: "shrink", width: 60, options: IM{"fontStyle": "", "minFontSize": 8}, want: "Lorem ipsum do…"},
+		{name: "empty", overflow: "ellipsis", width: 1, options: IM{}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpt.setPdfFont("", "", 10)
+			if got := rpt.fitText(text, tt.width, tt.overflow, tt.options); got != tt.want {
+				t.Errorf("Report.fitText() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReport_setElementValue_overflow(t *testing.T) {
+	rpt := New("p", "A4")
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "clip", want: "clip"},
+		{value: "truncate", want: "clip"},
+		{value: "ellipsis", want: "ellipsis"},
+		{value: "shrink", want: "shrink"},
+		{value: "scroll", want: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			cell, _ := rpt.getPageItem("cell")
+			err := rpt.setElementValue(&cell, "overflow", tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Report.setElementValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := cell.Item.(*Cell).Overflow; got != tt.want {
+				t.Errorf("Report.setElementValue() overflow = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitBorder(t *testing.T) {
+	tests := []struct {
+		border      string
+		first, last bool
+		want        string
+	}{
+		{border: "", first: true, last: false, want: ""},
+		{border: "1", first: false, last: false, want: "1"},
+		{border: "LR", first: true, last: false, want: "LRB"},
+		{border: "LR", first: false, last: true, want: "LRT"},
+		{border: "LRB", first: false, last: false, want: "LRBT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.border, func(t *testing.T) {
+			if got := splitBorder(tt.border, tt.first, tt.last); got != tt.want {
+				t.Errorf("splitBorder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReport_splitCellText(t *testing.T) {
+	rpt := New("p", "A4")
+	rpt.Pdf.AddPage()
+	text := "Lorem\nipsum\ndolor\nsit"
+	options := IM{"fontSize": float64(10), "padding": float64(0)}
+	tests := []struct {
+		name     string
+		text     string
+		height   float64
+		wantPart string
+		wantRest string
+		wantFits bool
+	}{
+		{name: "empty", text: "", height: 0, wantPart: "", wantRest: "", wantFits: true},
+		{name: "none", text: text, height: 5, wantPart: "", wantRest: text, wantFits: false},
+		{name: "split", text: text, height: 25, wantPart: "Lorem\nipsum", wantRest: "dolor\nsit", wantFits: true},
+		{name: "all", text: text, height: 100, wantPart: text, wantRest: "", wantFits: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			part, rest, fits := rpt.splitCellText(tt.text, 200, tt.height, options)
+			if part != tt.wantPart || rest != tt.wantRest || fits != tt.wantFits {
+				t.Errorf("Report.splitCellText() = %q, %q, %v, want %q, %q, %v", part, rest, fits, tt.wantPart, tt.wantRest, tt.wantFits)
+			}
+		})
+	}
+}
+
+func TestReport_createSplitRow(t *testing.T) {
+	text := strings.Repeat("Lorem ipsum dolor sit amet\n", 150)
+	tests := []struct {
+		name     string
+		template string
+		wantPage int
+	}{
+		{name: "row", wantPage: 4, template: `{"details": [
+			{"row": {"columns": [{"cell": {"value": "text", "border": "LR", "multiline": "true"}}]}}]}`},
+		{name: "datagrid", wantPage: 4, template: `{"details": [
+			{"datagrid": {"databind": "items", "columns": [{"column": {"fieldname": "text", "label": "Text"}}]}}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpt := New("p", "A4")
+			if err := rpt.LoadJSONDefinition(tt.template); err != nil {
+				t.Fatal(err)
+			}
+			rpt.SetData("text", text)
+			rpt.SetData("items", []SM{{"text": text}})
+			rpt.CreateReport()
+			if got := rpt.Pdf.PageNo(); got != tt.wantPage {
+				t.Errorf("Report.createSplitRow() pages = %v, want %v", got, tt.wantPage)
+			}
+			if _, pageHeight := rpt.Pdf.GetPageSize(); rpt.Pdf.GetY() > pageHeight-rpt.BottomMargin {
+				t.Errorf("Report.createSplitRow() y = %v, overflows the page", rpt.Pdf.GetY())
+			}
+		})
+	}
+}
+
+func TestReport_keepTogether(t *testing.T) {
+	rpt := New("p", "A4")
+	rpt.CreateReport()
+	tests := []struct {
+		name     string
+		height   float64
+		wantPage int
+	}{
+		{name: "fit", height: 10, wantPage: 1},
+		{name: "too_high", height: 10000, wantPage: 1},
+		{name: "break", height: 30, wantPage: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpt.Pdf.SetY(rpt.pageBreak - 20)
+			rpt.keepTogether(tt.height)
+			if got := rpt.Pdf.PageNo(); got != tt.wantPage {
+				t.Errorf("Report.keepTogether() page = %v, want %v", got, tt.wantPage)
+			}
+		})
+	}
+}
+
+func TestReport_getKeepHeight(t *testing.T) {
+	rpt := New("p", "A4")
+	rpt.CreateReport()
+	tests := []struct {
+		name     string
+		elements []PageItem
+		want     float64
+	}{
+		{name: "next", want: 12, elements: []PageItem{
+			{ItemType: "hline", Item: &HLine{Gap: 5, KeepWithNext: true}},
+			{ItemType: "vgap", Item: &VGap{Height: 7}},
+			{ItemType: "vgap", Item: &VGap{Height: 9}}}},
+		{name: "last", want: 8, elements: []PageItem{
+			{ItemType: "hline", Item: &HLine{Gap: 5, KeepWithNext: true}},
+			{ItemType: "hline", Item: &HLine{Gap: 3, KeepWithNext: true}}}},
+		{name: "group", want: 9, elements: []PageItem{
+			{ItemType: "hline", Item: &HLine{Gap: 5, KeepWithNext: true}},
+			{ItemType: "group", Item: &Group{Elements: []PageItem{
+				{ItemType: "vgap", Item: &VGap{Height: 4}},
+				{ItemType: "vgap", Item: &VGap{Height: 6}}}}}}},
+		{name: "group_together", want: 15, elements: []PageItem{
+			{ItemType: "hline", Item: &HLine{Gap: 5, KeepWithNext: true}},
+			{ItemType: "group", Item: &Group{KeepTogether: true, Elements: []PageItem{
+				{ItemType: "vgap", Item: &VGap{Height: 4}},
+				{ItemType: "vgap", Item: &VGap{Height: 6}}}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rpt.getKeepHeight(tt.elements); got != tt.want {
+				t.Errorf("Report.getKeepHeight() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReport_createElements_keep(t *testing.T) {
+	// the rows and the grid start 10mm above the page break
+	template := func(details string) string {
+		return `{"details": [{"vgap": {"height": 261}}, ` + details + `]}`
+	}
+	row := `{"row": {"height": 20, "columns": [{"cell": {"value": "Lorem"}}]}}`
+	tests := []struct {
+		name     string
+		template string
+		plain    string
+	}{
+		{name: "keep_with_next",
+			template: template(`{"row": {"keep-with-next": true, "columns": [{"cell": {"value": "Title"}}]}}, ` + row),
+			plain:    template(`{"row": {"columns": [{"cell": {"value": "Title"}}]}}, ` + row)},
+		{name: "keep_together",
+			template: template(`{"group": {"keep-together": true, "elements": [{"row": {"columns": [{"cell": {"value": "Title"}}]}}, ` + row + `]}}`),
+			plain:    template(`{"group": {"elements": [{"row": {"columns": [{"cell": {"value": "Title"}}]}}, ` + row + `]}}`)},
+		{name: "min_rows",
+			// the header and the first row fit in the first page
+			template: `{"details": [{"vgap": {"height": 255}}, {"datagrid": {"databind": "items", "min-rows": 3, "columns": [{"column": {"fieldname": "text", "label": "Text"}}]}}]}`,
+			plain:    `{"details": [{"vgap": {"height": 255}}, {"datagrid": {"databind": "items", "columns": [{"column": {"fieldname": "text", "label": "Text"}}]}}]}`},
+	}
+	// createReport returns the page number and the y position of the end of the report
+	createReport := func(template string) (int, float64) {
+		rpt := New("p", "A4")
+		if err := rpt.LoadJSONDefinition(template); err != nil {
+			t.Fatal(err)
+		}
+		rpt.SetData("items", []SM{{"text": "1"}, {"text": "2"}, {"text": "3"}})
+		rpt.CreateReport()
+		return rpt.Pdf.PageNo(), rpt.Pdf.GetY()
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keepPage, keepY := createReport(tt.template)
+			plainPage, plainY := createReport(tt.plain)
+			if keepPage != 2 || plainPage != 2 {
+				t.Fatalf("pages = %v, %v, want 2, 2", keepPage, plainPage)
+			}
+			// the kept elements are moved together to the second page
+			if keepY <= plainY {
+				t.Errorf("Report.createElements() y = %v, want greater than %v", keepY, plainY)
+			}
+		})
+	}
+}
+
+func TestReport_createElements_breaks(t *testing.T) {
+	row := `{"row": {"columns": [{"cell": {"value": "Lorem"}}]}}`
+	tests := []struct {
+		name     string
+		details  string
+		wantPage int
+	}{
+		{name: "before", wantPage: 2,
+			details: row + `, {"row": {"page-break-before": true, "columns": [{"cell": {"value": "Lorem"}}]}}`},
+		{name: "before_first", wantPage: 1,
+			details: `{"row": {"page-break-before": true, "columns": [{"cell": {"value": "Lorem"}}]}}`},
+		{name: "after", wantPage: 2,
+			details: `{"hline": {"page-break-after": true}}, ` + row},
+		{name: "after_last", wantPage: 1,
+			details: row + `, {"row": {"page-break-after": true, "columns": [{"cell": {"value": "Lorem"}}]}}`},
+		{name: "after_group", wantPage: 2,
+			details: `{"group": {"elements": [{"vgap": {"height": 5, "page-break-after": true}}]}}, ` + row},
+		{name: "need_space", wantPage: 2,
+			details: `{"vgap": {"height": 250}}, {"need-space": {"height": 30}}, ` + row},
+		{name: "need_space_fit", wantPage: 1,
+			details: `{"need-space": {"height": 30}}, ` + row},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpt := New("p", "A4")
+			if err := rpt.LoadJSONDefinition(`{"details": [` + tt.details + `]}`); err != nil {
+				t.Fatal(err)
+			}
+			rpt.CreateReport()
+			if got := rpt.Pdf.PageNo(); got != tt.wantPage {
+				t.Errorf("Report.createElements() pages = %v, want %v", got, tt.wantPage)
+			}
+		})
+	}
+}
+
+func TestReport_createBox(t *testing.T) {
+	template := `{"details": [{"row": {"columns": [
+		{"box": {"width": "30%", "elements": [{"row": {"columns": [{"cell": {"value": "Logo"}}]}}]}},
+		{"box": {"border": "1", "padding": 2, "background-color": 245, "elements": [
+			{"row": {"columns": [{"cell": {"value": "Name"}}]}},
+			{"row": {"columns": [{"cell": {"value": "Street"}}]}},
+			{"html": {"html": "<b>City</b>"}},
+			{"datagrid": {"databind": "items", "columns": [{"column": {"fieldname": "text", "label": "Text"}}]}}]}}]}}]}`
+	rpt := New("p", "A4")
+	if err := rpt.LoadJSONDefinition(template); err != nil {
+		t.Fatal(err)
+	}
+	rpt.SetData("items", []SM{{"text": "1"}, {"text": "2"}})
+	rpt.CreateReport()
+	row := rpt.details[0].Item.(*Row)
+	rpt.Pdf.SetXY(rpt.LeftMargin, rpt.pageTop)
+	logoHeight, logoWidth := rpt.createBox(row.Columns[0].Item.(*Box), 0, true)
+	pageWidth, _ := rpt.Pdf.GetPageSize()
+	if want := (pageWidth - rpt.LeftMargin - rpt.RightMargin) * 0.3; logoWidth != want {
+		t.Errorf("Report.createBox() width = %v, want %v", logoWidth, want)
+	}
+	rpt.Pdf.SetX(rpt.LeftMargin + logoWidth)
+	addressHeight, addressWidth := rpt.createBox(row.Columns[1].Item.(*Box), 0, true)
+	if want := pageWidth - rpt.RightMargin - rpt.LeftMargin - logoWidth; addressWidth != want {
+		t.Errorf("Report.createBox() width = %v, want %v", addressWidth, want)
+	}
+	if addressHeight <= logoHeight {
+		t.Errorf("Report.createBox() height = %v, want greater than %v", addressHeight, logoHeight)
+	}
+	rpt.Pdf.SetXY(rpt.LeftMargin, rpt.pageTop)
+	if got := rpt.createRow("details", row, false); got != addressHeight {
+		t.Errorf("Report.createRow() = %v, want %v", got, addressHeight)
+	}
+	if got := rpt.Pdf.GetY(); got != rpt.pageTop+addressHeight || rpt.Pdf.GetX() != rpt.LeftMargin {
+		t.Errorf("Report.createRow() position = %v, %v, want %v, %v", rpt.Pdf.GetX(), got, rpt.LeftMargin, rpt.pageTop+addressHeight)
+	}
+	if rpt.inBox || rpt.LeftMargin != _margin || rpt.RightMargin != _margin {
+		t.Errorf("Report.createBox() margins are not restored")
+	}
+}
+
+func TestReport_createAbsoluteRow(t *testing.T) {
+	rpt := New("p", "A4")
+	if err := rpt.LoadJSONDefinition(`{"details": [{"row": {"position": "absolute", "x": 100, "y": 50,
+		"columns": [{"cell": {"value": "Lorem", "border": "1"}}, {"barcode": {"code-type": "code39", "value": "1234"}}]}}]}`); err != nil {
+		t.Fatal(err)
+	}
+	rpt.CreateReport()
+	row := rpt.details[0].Item.(*Row)
+	if row.X != 100*_mmPt || row.Y != 50*_mmPt {
+		t.Errorf("Row position = %v, %v, want %v, %v", row.X, row.Y, 100*_mmPt, 50*_mmPt)
+	}
+	if got := rpt.Pdf.GetY(); got != rpt.pageTop || rpt.Pdf.GetX() != rpt.LeftMargin {
+		t.Errorf("Report.createAbsoluteRow() position = %v, %v, want %v, %v", rpt.Pdf.GetX(), got, rpt.LeftMargin, rpt.pageTop)
+	}
+	if got := rpt.createRow("details", row, true); got != 0 {
+		t.Errorf("Report.createRow() = %v, want 0", got)
+	}
+	if rpt.inBox || rpt.LeftMargin != _margin {
+		t.Errorf("Report.createAbsoluteRow() margins are not restored")
+	}
+}
+
+func TestGetTableCells(t *testing.T) {
+	rpt := New("p", "A4")
+	if err := rpt.LoadJSONDefinition(`{"details": [{"table": {"rows": [
+		{"row": {"columns": [{"cell": {"value": "A", "rowspan": 2}}, {"cell": {"value": "B", "colspan": 2}}]}},
+		{"row": {"columns": [{"cell": {"value": "C"}}, {"cell": {"value": "D", "rowspan": 5}}]}}]}}]}`); err != nil {
+		t.Fatal(err)
+	}
+	cells, cols := getTableCells(rpt.details[0].Item.(*Table).Rows)
+	if cols != 3 || len(cells) != 4 {
+		t.Fatalf("getTableCells() = %v, %v, want 4, 3", len(cells), cols)
+	}
+	want := []tableCell{
+		{row: 0, col: 0, rowspan: 2, colspan: 1}, {row: 0, col: 1, rowspan: 1, colspan: 2},
+		{row: 1, col: 1, rowspan: 1, colspan: 1}, {row: 1, col: 2, rowspan: 1, colspan: 1}}
+	for index, tc := range cells {
+		tc.cell = nil
+		if !reflect.DeepEqual(tc, want[index]) {
+			t.Errorf("getTableCells() cell %d = %v, want %v", index, tc, want[index])
+		}
+	}
+}
+
+func TestReport_createTable(t *testing.T) {
+	rpt := New("p", "A4")
+	if err := rpt.LoadJSONDefinition(`{"details": [{"table": {"widths": "40,20%", "rows": [
+		{"row": {"height": 10, "columns": [{"cell": {"value": "Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor", "rowspan": 2, "multiline": true}},
+			{"cell": {"value": "B", "colspan": 2}}]}},
+		{"row": {"columns": [{"cell": {"value": "C"}}, {"cell": {"value": "D"}}]}}]}}]}`); err != nil {
+		t.Fatal(err)
+	}
+	rpt.CreateReport()
+	table := rpt.details[0].Item.(*Table)
+	if table.Border != "1" || table.Widths != ToString(40*_mmPt, "0")+",20%" {
+		t.Errorf("Table = %v, %v", table.Border, table.Widths)
+	}
+	pageWidth, _ := rpt.Pdf.GetPageSize()
+	widths := rpt.getTableWidths(table, 3)
+	nwidth := pageWidth - rpt.LeftMargin - rpt.RightMargin
+	if widths[0] != 40*_mmPt || math.Abs(widths[1]-nwidth*0.2) > 1e-6 || math.Abs(widths[0]+widths[1]+widths[2]-nwidth) > 1e-6 {
+		t.Errorf("Report.getTableWidths() = %v", widths)
+	}
+	cy := rpt.Pdf.GetY()
+	rpt.createTable(table, true)
+	height := rpt.Pdf.GetY() - cy
+	if height <= 10*_mmPt {
+		t.Errorf("Report.createTable() height = %v, want the spanned cell height", height)
+	}
+	rpt.Pdf.SetY(cy)
+	rpt.createTable(table, false)
+	if got := rpt.Pdf.GetY() - cy; got != height || rpt.Pdf.GetX() != rpt.LeftMargin {
+		t.Errorf("Report.createTable() height = %v, want %v", got, height)
+	}
+	if got := rpt.getElementHeight(table, false); got != height {
+		t.Errorf("Report.getElementHeight() = %v, want %v", got, height)
+	}
+	rpt.createTable(&Table{}, false)
+}
+
+func TestReport_createWrapRow(t *testing.T) {
+	rpt := New("p", "A4")
+	if err := rpt.LoadJSONDefinition(`{"details": [
+		{"row": {"wrap": true, "hgap": 2, "columns": [
+			{"cell": {"value": "Label 1", "width": 50, "border": "1"}}, {"separator": {}},
+			{"cell": {"value": "Label 2", "width": 50, "border": "1"}}, {"separator": {}},
+			{"cell": {"value": "Label 3", "width": 50, "border": "1"}}, {"separator": {}},
+			{"cell": {"value": "Label 4", "width": 50, "border": "1"}}]}},
+		{"row": {"columns": [{"cell": {"value": "Label", "border": "1"}}]}}]}`); err != nil {
+		t.Fatal(err)
+	}
+	rpt.CreateReport()
+	row := rpt.details[0].Item.(*Row)
+	lines := rpt.getWrapLines("details", row)
+	if len(lines) != 2 || len(lines[0]) != 5 || len(lines[1]) != 1 {
+		t.Fatalf("Report.getWrapLines() = %v", lines)
+	}
+	lineHeight := rpt.createRow("details", rpt.details[1].Item.(*Row), true)
+	cy := rpt.Pdf.GetY()
+	if got := rpt.createRow("details", row, true); got != 2*lineHeight || rpt.Pdf.GetY()-cy != got {
+		t.Errorf("Report.createWrapRow() = %v, want %v", got, 2*lineHeight)
+	}
+	row.Wrap = false
+	rpt.Pdf.SetY(cy)
+	if got := rpt.createRow("details", row, true); got != lineHeight {
+		t.Errorf("Report.createRow() = %v, want %v", got, lineHeight)
+	}
+}
+
+func TestReport_nextPage(t *testing.T) {
+	rows := make([]string, 0)
+	for index := 0; index < 150; index++ {
+		rows = append(rows, `{"row": {"columns": [{"cell": {"value": "Lorem ipsum", "border": "1"}}]}}`)
+	}
+	template := `{"report": {"columns": 2, "column-gap": 5},
+		"header": [{"row": {"columns": [{"cell": {"value": "Header", "border": "1"}}]}}],
+		"details": [` + strings.Join(rows, ",") + `]}`
+	rpt := New("p", "A4")
+	if err := rpt.LoadJSONDefinition(template); err != nil {
+		t.Fatal(err)
+	}
+	if rpt.Columns != 2 || rpt.ColumnGap != 5*_mmPt {
+		t.Fatalf("Report columns = %v, %v", rpt.Columns, rpt.ColumnGap)
+	}
+	rpt.CreateReport()
+	if pageNo := rpt.Pdf.PageNo(); pageNo != 2 {
+		t.Errorf("Report.CreateReport() pages = %v, want 2", pageNo)
+	}
+	if rpt.LeftMargin != _margin || rpt.RightMargin != _margin {
+		t.Errorf("Report.CreateReport() margins = %v, %v, want %v", rpt.LeftMargin, rpt.RightMargin, _margin)
+	}
+
+	rpt.addPage()
+	pageNo := rpt.Pdf.PageNo()
+	pageWidth, _ := rpt.Pdf.GetPageSize()
+	width := (pageWidth - 2*_margin - 5*_mmPt) / 2
+	if rpt.column != 0 || rpt.LeftMargin != _margin || math.Abs(rpt.RightMargin-(pageWidth-_margin-width)) > 1e-6 {
+		t.Errorf("Report.addPage() column = %v, %v, %v", rpt.column, rpt.LeftMargin, rpt.RightMargin)
+	}
+	rpt.Pdf.SetY(rpt.pageTop + 100)
+	rpt.nextPage()
+	if rpt.Pdf.PageNo() != pageNo || rpt.column != 1 || math.Abs(rpt.LeftMargin-(_margin+width+5*_mmPt)) > 1e-6 ||
+		math.Abs(rpt.RightMargin-_margin) > 1e-6 || rpt.Pdf.GetY() != rpt.pageTop {
+		t.Errorf("Report.nextPage() column = %v, %v, %v", rpt.column, rpt.LeftMargin, rpt.RightMargin)
+	}
+	rpt.nextPage()
+	if rpt.Pdf.PageNo() != pageNo+1 || rpt.column != 0 || rpt.LeftMargin != _margin {
+		t.Errorf("Report.nextPage() page = %v, column = %v", rpt.Pdf.PageNo(), rpt.column)
+	}
+
+	for name, report := range map[string]string{
+		"datagrid_columns": `"report": {"columns": 2, "column-gap": 5}`,
+	} {
+		checkGridColumns(t, name, report)
+	}
+}
+
+// cellRecorder - a test Generator recording the x positions of the printed cells
+type cellRecorder struct {
+	Generator
+	cells []IM
+}
+
+func (gen *cellRecorder) Cell(options IM) {
+	gen.cells = append(gen.cells, IM{"x": gen.GetX(), "text": options["txtStr"], "h": options["h"], "valign": options["valignStr"]})
+	gen.Generator.Cell(options)
+}
+
+func (gen *cellRecorder) MultiCell(options IM) {
+	gen.cells = append(gen.cells, IM{"x": gen.GetX(), "text": options["txtStr"]})
+	gen.Generator.MultiCell(options)
+}
+
+// checkGridColumns checks the data rows of a datagrid continued in the next details columns or pages
+// are printed under the grid header
+func checkGridColumns(t *testing.T, name, report string) {
+	rows := make([]string, 0)
+	for index := 0; index < 150; index++ {
+		rows = append(rows, fmt.Sprintf(`{"text": "r%d"}`, index))
+	}
+	template := `{` + report + `,
+		"details": [{"datagrid": {"databind": "items", "columns": [{"column": {"fieldname": "text", "label": "Text"}}]}}],
+		"data": {"items": [` + strings.Join(rows, ",") + `]}}`
+	rpt := New("p", "A4")
+	if err := rpt.LoadJSONDefinition(template); err != nil {
+		t.Fatal(err)
+	}
+	recorder := &cellRecorder{Generator: rpt.Pdf}
+	rpt.Pdf = recorder
+	rpt.CreateReport()
+	headerX, headers := float64(0), 0
+	for _, cell := range recorder.cells {
+		switch text := cell["text"].(string); {
+		case text == "Text":
+			headerX = cell["x"].(float64)
+			headers++
+		case strings.HasPrefix(text, "r") && math.Abs(cell["x"].(float64)-headerX) > 1e-6:
+			t.Errorf("%s: datagrid row %s x = %v, want the header x %v", name, text, cell["x"], headerX)
+			return
+		}
+	}
+	if headers < 2 {
+		t.Errorf("%s: datagrid headers = %v, want at least 2", name, headers)
+	}
+}
+
+func TestGetLabelsLayout(t *testing.T) {
+	layout := getLabelsLayout(&Labels{Sheet: "Avery-L7160", Width: 60 * _mmPt, HGap: 0})
+	want := [8]float64{3, 7, 60 * _mmPt, 38.1 * _mmPt, 7.2 * _mmPt, 15.1 * _mmPt, 2.5 * _mmPt, 0}
+	for index := range want {
+		if math.Abs(layout[index]-want[index]) > 1e-6 {
+			t.Errorf("getLabelsLayout() = %v, want %v", layout, want)
+			break
+		}
+	}
+	if layout := getLabelsLayout(&Labels{Sheet: "missing", Columns: 2}); layout[0] != 2 || layout[2] != 0 {
+		t.Errorf("getLabelsLayout() = %v", layout)
+	}
+}
+
+func TestReport_createLabels(t *testing.T) {
+	records := make([]string, 0)
+	for index := 0; index < 40; index++ {
+		records = append(records, fmt.Sprintf(`{"name": "Name %d", "code": "%05d"}`, index, index))
+	}
+	rpt := New("p", "A4")
+	if err := rpt.LoadJSONDefinition(`{"details": [
+		{"row": {"columns": [{"cell": {"value": "Labels"}}]}},
+		{"labels": {"databind": "items", "sheet": "avery-l7160", "offset": 5, "border": "1", "padding": 2, "elements": [
+			{"row": {"columns": [{"cell": {"value": "={{items.name}}"}}]}},
+			{"row": {"columns": [{"barcode": {"code-type": "code128", "value": "={{items.code}}"}}]}}]}},
+		{"row": {"columns": [{"cell": {"value": "Next"}}]}}],
+		"data": {"items": [` + strings.Join(records, ",") + `]}}`); err != nil {
+		t.Fatal(err)
+	}
+	labels := rpt.details[1].Item.(*Labels)
+	if labels.Offset != 5 || labels.Padding != ToString(2*_mmPt, "") || len(labels.Elements) != 2 {
+		t.Fatalf("Labels = %v", labels)
+	}
+	rpt.CreateReport()
+	// the first row, 16 labels after the 5 used labels of the first sheet, 21 and 3 labels, the next row
+	if pageNo := rpt.Pdf.PageNo(); pageNo != 5 {
+		t.Errorf("Report.createLabels() pages = %v, want 5", pageNo)
+	}
+	if _, valid := rpt.data["items"].([]SM); !valid || rpt.inBox || rpt.LeftMargin != _margin {
+		t.Errorf("Report.createLabels() the data source and the margins are not restored")
+	}
+	if !strings.Contains(rpt.Save2Xml(), "Name 39") {
+		t.Errorf("Report.createLabels() missing record values")
+	}
+}
+
+// cellHook - a test Generator calling the hook function before every printed cell
+type cellHook struct {
+	Generator
+	hook func(options IM)
+}
+
+func (gen *cellHook) Cell(options IM) {
+	gen.hook(options)
+	gen.Generator.Cell(options)
+}
+
+func TestReport_createLabels_records(t *testing.T) {
+	rpt := New("p", "A4")
+	if err := rpt.LoadJSONDefinition(`{"details": [
+			{"labels": {"databind": "items", "sheet": "avery-l7160", "elements": [
+				{"row": {"columns": [{"cell": {"value": "={{items.name}}"}}]}}]}},
+			{"row": {"columns": [{"cell": {"value": "={{items.1.name}}"}}]}}],
+		"data": {"items": [{"name": "Name 1"}, {"name": "Name 2"}]}}`); err != nil {
+		t.Fatal(err)
+	}
+	texts := make([]string, 0)
+	rpt.Pdf = &cellHook{Generator: rpt.Pdf, hook: func(options IM) {
+		if _, valid := rpt.data["items"].([]SM); !valid {
+			t.Errorf("Report.createLabels() the data source is overwritten: %v", rpt.data["items"])
+		}
+		texts = append(texts, ToString(options["txtStr"], ""))
+	}}
+	rpt.CreateReport()
+	want := []string{"Name 1", "Name 2", "Name 2"}
+	if !reflect.DeepEqual(texts, want) || len(rpt.records) > 0 {
+		t.Errorf("Report.createLabels() cells = %v, want %v", texts, want)
+	}
+}
+
+func TestReport_createLabels_header(t *testing.T) {
+	rpt := New("p", "A4")
+	if err := rpt.LoadJSONDefinition(`{
+		"header": [{"row": {"columns": [{"cell": {"value": "Header"}}]}}],
+		"details": [
+			{"labels": {"databind": "items", "sheet": "avery-l7160", "elements": [
+				{"row": {"columns": [{"cell": {"value": "={{items.name}}"}}]}}]}},
+			{"row": {"columns": [{"cell": {"value": "Next"}}]}}],
+		"data": {"items": [{"name": "Name 1"}, {"name": "Name 2"}]}}`); err != nil {
+		t.Fatal(err)
+	}
+	recorder := &cellRecorder{Generator: rpt.Pdf}
+	rpt.Pdf = recorder
+	rpt.CreateReport()
+	headers := 0
+	for _, cell := range recorder.cells {
+		if cell["text"] == "Header" {
+			headers++
+		}
+	}
+	// the first page with the header, the label sheet page and the next page with the header
+	if pageNo := rpt.Pdf.PageNo(); pageNo != 3 || headers != 2 || rpt.labelPage {
+		t.Errorf("Report.createLabels() pages = %v, headers = %v, want 3, 2", pageNo, headers)
+	}
+
+	if err := rpt.LoadJSONDefinition(`{"details": [{"labels": {"databind": "items", "elements": [
+		{"group": {"elements": []}}]}}]}`); err == nil {
+		t.Errorf("Report.LoadJSONDefinition() labels group error = nil")
+	}
+	labels, _ := rpt.AppendElement("details", "labels")
+	for _, ename := range []string{"group", "need-space", "labels"} {
+		if _, err := rpt.AppendElement(labels, ename); err == nil {
+			t.Errorf("Report.AppendElement() labels %s error = nil", ename)
+		}
+	}
+}
+
+func TestReport_createSection(t *testing.T) {
+	rpt := New("p", "A4")
+	if err := rpt.LoadJSONDefinition(`{
+		"header": [{"row": {"columns": [{"cell": {"value": "Report header"}}]}}],
+		"details": [
+			{"section": {"header": [], "top-margin": 20, "elements": [{"row": {"columns": [{"cell": {"name": "cover", "value": "{{page}}"}}]}}]}},
+			{"section": {"orientation": "landscape", "format": "a5", "restart-page-number": true,
+				"header": [{"row": {"columns": [{"cell": {"value": "Item list"}}]}}],
+				"elements": [{"row": {"columns": [{"cell": {"name": "items", "value": "{{page}}"}}]}}]}},
+			{"row": {"columns": [{"cell": {"name": "last", "value": "{{page}}"}}]}}]}`); err != nil {
+		t.Fatal(err)
+	}
+	section := rpt.details[1].Item.(*Section)
+	if section.Format != "a5" || section.Orientation != "l" || !section.RestartPageNumber || len(section.Header) != 1 || section.Footer != nil {
+		t.Fatalf("Section = %v", section)
+	}
+	if ToFloat(rpt.details[0].Item.(*Section).TopMargin, 0) != 20*_mmPt {
+		t.Errorf("Section TopMargin = %v", rpt.details[0].Item.(*Section).TopMargin)
+	}
+	rpt.CreateReport()
+	if pageNo := rpt.Pdf.PageNo(); pageNo != 3 {
+		t.Errorf("Report.CreateReport() pages = %v, want 3", pageNo)
+	}
+	if width, height := rpt.Pdf.GetPageSize(); width > height || rpt.TopMargin != _margin || len(rpt.header) != 1 {
+		t.Errorf("Report.createSection() the report settings are not restored")
+	}
+	xml := rpt.Save2Xml()
+	for _, value := range []string{"<cover><![CDATA[1]]></cover>", "<items><![CDATA[1]]></items>", "<last><![CDATA[2]]></last>"} {
+		if !strings.Contains(xml, value) {
+			t.Errorf("Report.createSection() missing page number: %s", value)
+		}
+	}
+	pdf, _ := rpt.Save2Pdf()
+	if !strings.Contains(string(pdf), "/MediaBox [ 0 0 595.00 420.00 ]") {
+		t.Errorf("Report.createSection() missing landscape A5 page")
+	}
+}
+
+func TestReport_SectionHeader(t *testing.T) {
+	rpt := New("p", "A4")
+	columns, _ := rpt.AppendElement("footer", "row")
+	rpt.AppendElement(columns, "cell", IM{"value": "Footer"})
+	section, _ := rpt.AppendElement("details", "section", IM{"left-margin": 0})
+	if margin := rpt.getSection(section).LeftMargin; margin != "0" {
+		t.Fatalf("Section LeftMargin = %v, want 0", margin)
+	}
+	columns, _ = rpt.AppendElement(section, "row")
+	rpt.AppendElement(columns, "cell", IM{"value": "Section"})
+	header := rpt.SectionHeader(section)
+	columns, _ = rpt.AppendElement(header, "row")
+	rpt.AppendElement(columns, "cell", IM{"value": "Section header"})
+	if _, err := rpt.AppendElement(header, "html"); err == nil {
+		t.Errorf("Report.AppendElement() section header html error = nil")
+	}
+	if footer := rpt.SectionFooter(section); footer == nil || len(*footer) != 0 {
+		t.Errorf("Report.SectionFooter() = %v", footer)
+	}
+	if rpt.SectionHeader(&rpt.details) != nil {
+		t.Errorf("Report.SectionHeader() details want nil")
+	}
+	recorder := &cellRecorder{Generator: rpt.Pdf}
+	rpt.Pdf = recorder
+	rpt.CreateReport()
+	texts := SM{}
+	for _, cell := range recorder.cells {
+		texts[cell["text"].(string)] = ToString(cell["x"], "")
+	}
+	if texts["Section"] != "0" || texts["Section header"] != "0" {
+		t.Errorf("Report.createSection() x = %v, want the explicit 0 margin", texts)
+	}
+	if _, found := texts["Footer"]; found {
+		t.Errorf("Report.createSection() the empty section footer prints the report footer")
+	}
+}
+
+func TestReport_getPageSections(t *testing.T) {
+	rpt := New("p", "A4")
+	if err := rpt.LoadJSONDefinition(`{
+		"header": [{"row": {"columns": [{"cell": {"value": "Header"}}]}}],
+		"first-header": [{"row": {"height": 40, "columns": [{"cell": {"value": "Letterhead"}}]}}],
+		"footer": [{"row": {"columns": [{"cell": {"value": "Footer"}}]}}],
+		"even-footer": [{"vgap": {"height": 20}}, {"row": {"columns": [{"cell": {"value": "Even footer", "align": "right"}}]}}],
+		"details": [{"row": {"columns": [{"cell": {"value": "Lorem"}}]}}]}`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rpt.AppendElement("even-header", "html"); err == nil {
+		t.Errorf("Report.AppendElement() want error")
+	}
+	if _, err := rpt.AppendElement("first-footer", "row"); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		pageNo         int
+		header, footer []PageItem
+	}{
+		{pageNo: 1, header: rpt.firstHeader, footer: rpt.firstFooter},
+		{pageNo: 2, header: rpt.header, footer: rpt.evenFooter},
+		{pageNo: 3, header: rpt.header, footer: rpt.footer},
+	}
+	for _, tt := range tests {
+		header, footer := rpt.getPageSections(tt.pageNo)
+		if !reflect.DeepEqual(header, tt.header) || !reflect.DeepEqual(footer, tt.footer) {
+			t.Errorf("Report.getPageSections(%d) = %v, %v", tt.pageNo, header, footer)
+		}
+	}
+	rpt.CreateReport()
+	firstTop := rpt.pageTop
+	rpt.addPage()
+	evenTop, evenFooter := rpt.pageTop, rpt.footerHeight
+	rpt.addPage()
+	if firstTop <= evenTop || evenTop != rpt.pageTop || evenFooter <= rpt.footerHeight {
+		t.Errorf("Report.addPage() page top = %v, %v, %v footer height = %v, %v",
+			firstTop, evenTop, rpt.pageTop, evenFooter, rpt.footerHeight)
+	}
+}
+
+func TestReport_getPageMargins(t *testing.T) {
+	rpt := New("p", "A4")
+	if err := rpt.LoadJSONDefinition(`{"report": {"left-margin": 20, "right-margin": 10, "mirror-margins": true, "gutter": 5},
+		"header": [{"row": {"columns": [{"cell": {"value": "Header", "align": "outside"}}]}}],
+		"details": [{"row": {"columns": [{"cell": {"value": "Lorem"}}]}}]}`); err != nil {
+		t.Fatal(err)
+	}
+	if align := rpt.header[0].Item.(*Row).Columns[0].Item.(*Cell).Align; align != "O" {
+		t.Errorf("Cell Align = %v, want O", align)
+	}
+	rpt.CreateReport()
+	inner, outer := 20*_mmPt+5*_mmPt, 10*_mmPt
+	if left, right := rpt.getPageMargins(1); left != inner || right != outer {
+		t.Errorf("Report.getPageMargins(1) = %v, %v, want %v, %v", left, right, inner, outer)
+	}
+	if left, right := rpt.getPageMargins(2); left != outer || right != inner {
+		t.Errorf("Report.getPageMargins(2) = %v, %v, want %v, %v", left, right, outer, inner)
+	}
+	if rpt.pageAlign("I") != "L" || rpt.pageAlign("O") != "R" || rpt.pageAlign("C") != "C" {
+		t.Errorf("Report.pageAlign() odd page")
+	}
+	rpt.addPage()
+	if rpt.LeftMargin != outer || rpt.RightMargin != inner || rpt.pageAlign("I") != "R" || rpt.pageAlign("O") != "L" {
+		t.Errorf("Report.addPage() even page margins = %v, %v", rpt.LeftMargin, rpt.RightMargin)
+	}
+	rpt.MirrorMargins = false
+	if left, right := rpt.getPageMargins(2); left != inner || right != outer || rpt.pageAlign("I") != "L" {
+		t.Errorf("Report.getPageMargins(2) = %v, %v, want %v, %v", left, right, inner, outer)
+	}
+	for _, vname := range []string{"Align", "HeaderAlign", "FooterAlign"} {
+		if align := rpt.parseValue(vname, "inside"); align != "I" {
+			t.Errorf("Report.parseValue(%s) = %v, want I", vname, align)
+		}
+	}
+	checkGridColumns(t, "datagrid_mirror", `"report": {"left-margin": 40, "right-margin": 10, "mirror-margins": true}`)
+}
+
+func TestGetPageFormat(t *testing.T) {
+	tests := []struct {
+		format        string
+		width, height float64
+		valid         bool
+	}{
+		{format: "DL", width: 110 * _mmPt, height: 220 * _mmPt, valid: true},
+		{format: "80x200", width: 80 * _mmPt, height: 200 * _mmPt, valid: true},
+		{format: "80 x 200mm", width: 80 * _mmPt, height: 200 * _mmPt, valid: true},
+		{format: "4x6in", width: 288, height: 432, valid: true},
+		{format: "10cm x 4in", width: 100 * _mmPt, height: 288, valid: true},
+		{format: "612×792pt", width: 612, height: 792, valid: true},
+		{format: "80x", valid: false},
+		{format: "0x200", height: 200 * _mmPt, valid: false},
+		{format: "missing", valid: false},
+	}
+	for _, tt := range tests {
+		width, height, valid := getPageFormat(tt.format)
+		if math.Abs(width-tt.width) > 1e-6 || math.Abs(height-tt.height) > 1e-6 || valid != tt.valid {
+			t.Errorf("getPageFormat(%s) = %v, %v, %v, want %v, %v, %v", tt.format, width, height, valid, tt.width, tt.height, tt.valid)
+		}
+	}
+	rpt := New("l", "80x200mm")
+	if rpt.format != ToString(80*_mmPt, "")+"x"+ToString(200*_mmPt, "")+"pt" {
+		t.Errorf("New() format = %v", rpt.format)
+	}
+	if width, height := rpt.Pdf.GetPageSize(); math.Abs(width-200*_mmPt) > 1e-6 || math.Abs(height-80*_mmPt) > 1e-6 {
+		t.Errorf("New() page size = %v, %v", width, height)
+	}
+	if rpt = New("p", "B5"); rpt.format != "b5" {
+		t.Errorf("New() format = %v, want b5", rpt.format)
+	}
+	if rpt = New("p", "B9"); rpt.format != _format {
+		t.Errorf("New() format = %v, want %v", rpt.format, _format)
+	}
+}
+
+func TestReport_setContinuousPage(t *testing.T) {
+	items := make([]string, 0)
+	for index := 0; index < 120; index++ {
+		items = append(items, fmt.Sprintf(`{"name": "Item %d", "price": "%d.00"}`, index, index))
+	}
+	rpt := New("p", "80x200mm")
+	if err := rpt.LoadJSONDefinition(`{"report": {"continuous": true, "left-margin": 4, "right-margin": 4},
+		"header": [{"row": {"columns": [{"cell": {"value": "Receipt", "align": "center"}}]}}, {"hline": {}}],
+		"footer": [{"row": {"columns": [{"cell": {"value": "Thank you", "align": "center"}}]}}],
+		"details": [
+			{"datagrid": {"databind": "items", "border": "0", "columns": [
+				{"column": {"fieldname": "name", "label": "Item"}}, {"column": {"fieldname": "price", "label": "Price", "align": "right"}}]}},
+			{"vgap": {"height": 2, "page-break": true}},
+			{"html": {"html": "<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore</p>"}},
+			{"row": {"columns": [{"cell": {"value": "Total", "font-style": "bold"}}]}}],
+		"data": {"items": [` + strings.Join(items, ",") + `]}}`); err != nil {
+		t.Fatal(err)
+	}
+	if !rpt.Continuous {
+		t.Fatalf("Report.Continuous = false")
+	}
+	rpt.CreateReport()
+	width, height := rpt.Pdf.GetPageSize()
+	if pageNo := rpt.Pdf.PageNo(); pageNo != 1 || math.Abs(width-80*_mmPt) > 1e-6 || height <= 200*_mmPt {
+		t.Errorf("Report.CreateReport() pages = %v, page size = %v, %v", pageNo, width, height)
+	}
+	if bottom := height - rpt.BottomMargin - rpt.footerHeight; rpt.Pdf.GetY() > bottom+1e-6 || rpt.Pdf.GetY() < bottom-10 {
+		t.Errorf("Report.CreateReport() details end = %v, footer top = %v", rpt.Pdf.GetY(), bottom)
+	}
+}
+
+func TestReport_setContinuousPage_sections(t *testing.T) {
+	rows := make([]string, 0)
+	for index := 0; index < 60; index++ {
+		rows = append(rows, fmt.Sprintf(`{"row": {"columns": [{"cell": {"value": "Row %d"}}]}}`, index))
+	}
+	tests := []struct {
+		name       string
+		definition string
+		minHeight  float64
+	}{
+		{name: "first_header", definition: `{"report": {"continuous": true},
+			"header": [{"row": {"columns": [{"cell": {"value": "Header"}}]}}],
+			"first-header": [{"vgap": {"height": 300}}, {"row": {"columns": [{"cell": {"value": "First"}}]}}],
+			"footer": [{"row": {"columns": [{"cell": {"value": "Footer"}}]}}],
+			"details": [` + strings.Join(rows, ",") + `]}`},
+		{name: "section", definition: `{"report": {"continuous": true},
+			"footer": [{"row": {"columns": [{"cell": {"value": "Footer"}}]}}],
+			"details": [{"row": {"columns": [{"cell": {"value": "Start"}}]}},
+				{"section": {"format": "a5", "orientation": "landscape", "left-margin": 0,
+					"header": [{"row": {"columns": [{"cell": {"value": "Section"}}]}}],
+					"elements": [` + strings.Join(rows, ",") + `]}},
+				{"row": {"columns": [{"cell": {"value": "End"}}]}}]}`},
+		{name: "labels", definition: `{"report": {"continuous": true},
+			"footer": [{"row": {"columns": [{"cell": {"value": "Footer"}}]}}],
+			"details": [{"row": {"columns": [{"cell": {"value": "Start"}}]}},
+				{"labels": {"databind": "items", "sheet": "avery-l7160", "offset": 2, "elements": [
+					{"row": {"columns": [{"cell": {"value": "={{items.name}}"}}]}}]}},
+				{"row": {"columns": [{"cell": {"value": "End"}}]}}],
+			"data": {"items": [{"name": "1"}, {"name": "2"}, {"name": "3"}, {"name": "4"}, {"name": "5"}]}}`,
+			// the 2 skipped and the 5 printed labels in 3 rows
+			minHeight: 3 * 38.1 * _mmPt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpt := New("p", "A4")
+			if err := rpt.LoadJSONDefinition(tt.definition); err != nil {
+				t.Fatal(err)
+			}
+			recorder := &cellRecorder{Generator: rpt.Pdf}
+			rpt.Pdf = recorder
+			rpt.CreateReport()
+			width, height := rpt.Pdf.GetPageSize()
+			if pageNo := rpt.Pdf.PageNo(); pageNo != 1 || math.Abs(width-595.28) > 1 || height < tt.minHeight {
+				t.Errorf("Report.CreateReport() pages = %v, page size = %v, %v", pageNo, width, height)
+			}
+			if bottom := height - rpt.BottomMargin - rpt.footerHeight; rpt.Pdf.GetY() > bottom+1e-6 || rpt.Pdf.GetY() < bottom-20 {
+				t.Errorf("Report.CreateReport() page height = %v, details end = %v, footer top = %v", height, rpt.Pdf.GetY(), bottom)
+			}
+			for _, cell := range recorder.cells {
+				if cell["text"] == "Section" {
+					t.Errorf("Report.CreateReport() the section header is printed")
+				}
+			}
+		})
+	}
+}
+
+func TestGetLength(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		unit     float64
+		defValue float64
+		want     float64
+	}{
+		{value: 10, unit: _mmPt, want: 10 * _mmPt},
+		{value: 1.5, unit: 72, want: 108},
+		{value: "12pt", unit: _mmPt, want: 12},
+		{value: "1.5in", unit: _mmPt, want: 108},
+		{value: "2cm", unit: 1, want: 20 * _mmPt},
+		{value: " 10 MM ", unit: 1, want: 10 * _mmPt},
+		{value: "10", unit: 72, want: 720},
+		{value: "10px", unit: 1, defValue: 5, want: 5},
+		{value: 0, unit: _mmPt, defValue: 5, want: 0},
+		{value: "0mm", unit: 1, defValue: 5, want: 0},
+		{value: "", unit: 1, defValue: 5, want: 5},
+		{value: true, unit: 1, defValue: 5, want: 5},
+	}
+	for _, tt := range tests {
+		if got := getLength(tt.value, tt.unit, tt.defValue); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("getLength(%v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestReport_SetReportValue_length(t *testing.T) {
+	rpt := New("p", "A4")
+	for _, fieldname := range []string{"left-margin", "top-margin", "right-margin", "bottom-margin", "gutter", "column-gap"} {
+		rpt.SetReportValue(fieldname, 5)
+		rpt.SetReportValue(fieldname, 0)
+	}
+	if rpt.LeftMargin != 0 || rpt.TopMargin != 0 || rpt.RightMargin != 0 || rpt.BottomMargin != 0 ||
+		rpt.Gutter != 0 || rpt.ColumnGap != 0 {
+		t.Errorf("Report.SetReportValue() zero lengths = %v, %v, %v, %v, %v, %v",
+			rpt.LeftMargin, rpt.TopMargin, rpt.RightMargin, rpt.BottomMargin, rpt.Gutter, rpt.ColumnGap)
+	}
+	rpt.SetReportValue("left-margin", "bogus")
+	rpt.SetReportValue("font-size", 12)
+	rpt.SetReportValue("font-size", 0)
+	if rpt.LeftMargin != 0 || rpt.FontSize != 12 {
+		t.Errorf("Report.SetReportValue() invalid lengths = %v, %v", rpt.LeftMargin, rpt.FontSize)
+	}
+	rpt.SetReportValue("padding", "2pt")
+	rpt.SetReportValue("line-height", "14pt")
+	rpt.SetReportValue("padding", "bogus")
+	rpt.SetReportValue("line-height", "bogus")
+	if rpt.Padding != "2" || rpt.LineHeight != "14" {
+		t.Errorf("Report.SetReportValue() padding, line height = %v, %v, want 2, 14", rpt.Padding, rpt.LineHeight)
+	}
+	rpt.SetReportValue("padding", 0)
+	if rpt.Padding != "0" {
+		t.Errorf("Report.SetReportValue() padding = %v, want 0", rpt.Padding)
+	}
+}
+
+func TestReport_getUnit(t *testing.T) {
+	rpt := New("p", "A4")
+	if err := rpt.LoadJSONDefinition(`{"report": {"left-margin": 1, "top-margin": "10mm", "font-size": "12pt", "unit": "in"},
+		"details": [{"row": {"height": 0.5, "columns": [
+			{"cell": {"value": "Lorem", "width": "2cm", "padding": "4pt", "font-size": 10}},
+			{"barcode": {"code-type": "code39", "value": "1234", "wide": 1, "narrow": "5mm"}}]}}]}`); err != nil {
+		t.Fatal(err)
+	}
+	if rpt.Unit != "in" || rpt.getUnit() != 72 || rpt.LeftMargin != 72 || rpt.TopMargin != 10*_mmPt || rpt.FontSize != 12 {
+		t.Errorf("Report unit = %v, margins = %v, %v, font size = %v", rpt.Unit, rpt.LeftMargin, rpt.TopMargin, rpt.FontSize)
+	}
+	row := rpt.details[0].Item.(*Row)
+	cell := row.Columns[0].Item.(*Cell)
+	barcode := row.Columns[1].Item.(*Barcode)
+	if row.Height != 36 || ToFloat(cell.Width, 0) != 20*_mmPt || cell.Padding != "4" || cell.FontSize != 10 {
+		t.Errorf("Row height = %v, cell width = %v, padding = %v, font size = %v", row.Height, cell.Width, cell.Padding, cell.FontSize)
+	}
+	if barcode.Width != 72 || math.Abs(barcode.Height-5*_mmPt) > 1e-6 {
+		t.Errorf("Barcode size = %v, %v", barcode.Width, barcode.Height)
+	}
+	if err := rpt.SetReportValue("unit", "px"); err != nil || rpt.Unit != "in" {
+		t.Errorf("Report.SetReportValue() unit = %v", rpt.Unit)
+	}
+}
+
+func TestCellPadding(t *testing.T) {
+	left, top, right, bottom := cellPadding(IM{"padding": float64(3), "paddingLeft": float64(5)})
+	if left != 5 || top != 3 || right != 3 || bottom != 3 {
+		t.Errorf("cellPadding() = %v, %v, %v, %v, want 5, 3, 3, 3", left, top, right, bottom)
+	}
+	if left, _, right, _ = cellPadding(IM{}); left != 0 || right != 0 {
+		t.Errorf("cellPadding() = %v, %v, want 0, 0", left, right)
+	}
+}
+
+func TestReport_FontError(t *testing.T) {
+	rpt := New("p", "A4", "Roboto", "../../data/fonts")
+	if rpt.FontFamily != "Cabin" || rpt.FontError() == nil {
+		t.Errorf("New() font family = %v, error = %v", rpt.FontFamily, rpt.FontError())
+	}
+	if !strings.Contains(rpt.FontError().Error(), "Roboto") {
+		t.Errorf("Report.FontError() = %v", rpt.FontError())
+	}
+
+	rpt = New("p", "A4")
+	if err := rpt.SetReportValue("font-family", "Arial"); err == nil || rpt.FontFamily != "Cabin" {
+		t.Errorf("Report.SetReportValue() error = %v, font family = %v", err, rpt.FontFamily)
+	}
+	if _, err := rpt.AppendElement("details", "html", IM{"font-family": "Arial"}); err == nil {
+		t.Error("Report.AppendElement() unregistered font family error = nil")
+	}
+	if err := rpt.LoadJSONDefinition(`{"details": [{"row": {"columns": [{"cell": {"value": "Lorem", "font-family": "Arial"}}]}}]}`); err == nil {
+		t.Error("Report.LoadJSONDefinition() unregistered font family error = nil")
+	}
+	rpt.details = append(rpt.details, PageItem{ItemType: "row", Item: &Row{
+		Columns: []PageItem{{ItemType: "cell", Item: &Cell{Value: "Lorem", FontFamily: "Arial"}}}}})
+	if !rpt.CreateReport() || rpt.FontError() == nil {
+		t.Errorf("Report.CreateReport() font error = %v", rpt.FontError())
+	}
+
+	// an invalid font file leaves the family unregistered
+	fontDir := t.TempDir()
+	data, _ := Fonts.ReadFile("fonts/Cabin-Regular.ttf")
+	_ = os.WriteFile(path.Join(fontDir, "Mono-Regular.ttf"), data, 0600)
+	_ = os.WriteFile(path.Join(fontDir, "Mono-Bold.ttf"), []byte("font"), 0600)
+	if err := rpt.AddFontFamily("Mono", fontDir); err == nil {
+		t.Error("Report.AddFontFamily() invalid font file error = nil")
+	}
+	if _, found := rpt.fonts["Mono"]; found {
+		t.Error("Report.AddFontFamily() the family is partly registered")
+	}
+	if err := rpt.Pdf.(*genGoPDF).pdf.SetFont("Mono", "", 10); err == nil {
+		t.Error("Report.AddFontFamily() the valid font of an invalid family is added to the generator")
+	}
+	for _, style := range []string{"L", "left", "l", "underline"} {
+		if err := rpt.AddFontData("Mono", style, data); err == nil {
+			t.Errorf("Report.AddFontData() style %q error = nil", style)
+		}
+	}
+	if err := rpt.AddFontData("Mono", "Bold", data); err != nil || rpt.fonts["Mono"]["B"] != "B" {
+		t.Errorf("Report.AddFontData() error = %v", err)
+	}
+}
+
+func TestGenGoPDF_SetFontSize(t *testing.T) {
+	rpt := New("p", "A4")
+	_ = rpt.AddFontFS(Fonts, "Serif", "", "fonts/Cabin-Regular.ttf")
+	rpt.FallbackFonts = "Serif"
+	rpt.Pdf.AddPage()
+	rpt.Pdf.SetFont("Cabin", "", 10)
+	width := rpt.Pdf.GetTextWidth("Cabin 中")
+	rpt.Pdf.SetFontSize(20)
+	if size := rpt.Pdf.GetFontSize(); size != 20 {
+		t.Errorf("genGoPDF.GetFontSize() = %v, want %v", size, 20)
+	}
+	if got := rpt.Pdf.GetTextWidth("Cabin 中"); math.Abs(got-2*width) > 0.01 {
+		t.Errorf("genGoPDF.GetTextWidth() = %v, want %v", got, 2*width)
+	}
+}
@@ -0,0 +1,273 @@
+package report
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+/*
+Shaper - an optional text shaping step. The Shape function returns the text with the contextual
+forms of the characters in logical order. A text is shaped once before the bidirectional reordering,
+and the width of the text is measured from the same shaped text, so the measured and the printed widths match.
+
+The gopdf generator prints every character with a single glyph of the font, without the OpenType glyph
+substitutions. The built-in shapers work within this limit:
+  - ArabicShaper replaces the Arabic (and Persian) letters with their presentation forms
+  - IndicShaper reorders the pre-base vowel signs of the Indic scripts (e.g. Devanagari, Bengali, Tamil).
+    It does not shape the conjuncts: the consonant clusters are printed with the visible virama (halant)
+    sign, unless the caller maps them to the glyph characters of the used font.
+
+Example:
+
+	rpt := report.New("P", "A4")
+	rpt.SetShaper(report.Shapers{report.ArabicShaper{}, report.IndicShaper{}})
+*/
+type Shaper interface {
+	Shape(text string) string
+}
+
+// SetShaper - Set the text shaper of the report. The nil value disables the text shaping.
+func (rpt *Report) SetShaper(shaper Shaper) {
+	rpt.shaper = shaper
+}
+
+// shapeText returns the shaped text of the report Shaper
+func (rpt *Report) shapeText(text string) string {
+	if rpt.shaper == nil {
+		return text
+	}
+	return rpt.shaper.Shape(text)
+}
+
+// Shapers - a list of Shapers applied in order
+type Shapers []Shaper
+
+// Shape returns the text shaped by all Shapers of the list
+func (shapers Shapers) Shape(text string) string {
+	for _, shaper := range shapers {
+		text = shaper.Shape(text)
+	}
+	return text
+}
+
+// ArabicShaper - the built-in Shaper of the Arabic script. The Arabic letters are replaced with their
+// isolated, final, initial or medial presentation forms, and the lam-alef pairs with their ligatures.
+type ArabicShaper struct{}
+
+// arabicForms - the isolated, final, initial and medial presentation forms of the Arabic letters.
+// The right-joining letters have only isolated and final forms.
+var arabicForms = map[rune][4]rune{
+	'ء': {'ﺀ', 0, 0, 0},
+	'آ': {'ﺁ', 'ﺂ', 0, 0},
+	'أ': {'ﺃ', 'ﺄ', 0, 0},
+	'ؤ': {'ﺅ', 'ﺆ', 0, 0},
+	'إ': {'ﺇ', 'ﺈ', 0, 0},
+	'ئ': {'ﺉ', 'ﺊ', 'ﺋ', 'ﺌ'},
+	'ا': {'ﺍ', 'ﺎ', 0, 0},
+	'ب': {'ﺏ', 'ﺐ', 'ﺑ', 'ﺒ'},
+	'ة': {'ﺓ', 'ﺔ', 0, 0},
+	'ت': {'ﺕ', 'ﺖ', 'ﺗ', 'ﺘ'},
+	'ث': {'ﺙ', 'ﺚ', 'ﺛ', 'ﺜ'},
+	'ج': {'ﺝ', 'ﺞ', 'ﺟ', 'ﺠ'},
+	'ح': {'ﺡ', 'ﺢ', 'ﺣ', 'ﺤ'},
+	'خ': {'ﺥ', 'ﺦ', 'ﺧ', 'ﺨ'},
+	'د': {'ﺩ', 'ﺪ', 0, 0},
+	'ذ': {'ﺫ', 'ﺬ', 0, 0},
+	'ر': {'ﺭ', 'ﺮ', 0, 0},
+	'ز': {'ﺯ', 'ﺰ', 0, 0},
+	'س': {'ﺱ', 'ﺲ', 'ﺳ', 'ﺴ'},
+	'ش': {'ﺵ', 'ﺶ', 'ﺷ', 'ﺸ'},
+	'ص': {'ﺹ', 'ﺺ', 'ﺻ', 'ﺼ'},
+	'ض': {'ﺽ', 'ﺾ', 'ﺿ', 'ﻀ'},
+	'ط': {'ﻁ', 'ﻂ', 'ﻃ', 'ﻄ'},
+	'ظ': {'ﻅ', 'ﻆ', 'ﻇ', 'ﻈ'},
+	'ع': {'ﻉ', 'ﻊ', 'ﻋ', 'ﻌ'},
+	'غ': {'ﻍ', 'ﻎ', 'ﻏ', 'ﻐ'},
+	'ـ': {'ـ', 'ـ', 'ـ', 'ـ'},
+	'ف': {'ﻑ', 'ﻒ', 'ﻓ', 'ﻔ'},
+	'ق': {'ﻕ', 'ﻖ', 'ﻗ', 'ﻘ'},
+	'ك': {'ﻙ', 'ﻚ', 'ﻛ', 'ﻜ'},
+	'ل': {'ﻝ', 'ﻞ', 'ﻟ', 'ﻠ'},
+	'م': {'ﻡ', 'ﻢ', 'ﻣ', 'ﻤ'},
+	'ن': {'ﻥ', 'ﻦ', 'ﻧ', 'ﻨ'},
+	'ه': {'ﻩ', 'ﻪ', 'ﻫ', 'ﻬ'},
+	'و': {'ﻭ', 'ﻮ', 0, 0},
+	'ى': {'ﻯ', 'ﻰ', 0, 0},
+	'ي': {'ﻱ', 'ﻲ', 'ﻳ', 'ﻴ'},
+	'پ': {'ﭖ', 'ﭗ', 'ﭘ', 'ﭙ'},
+	'چ': {'ﭺ', 'ﭻ', 'ﭼ', 'ﭽ'},
+	'ژ': {'ﮊ', 'ﮋ', 0, 0},
+	'ک': {'ﮎ', 'ﮏ', 'ﮐ', 'ﮑ'},
+	'گ': {'ﮒ', 'ﮓ', 'ﮔ', 'ﮕ'},
+	'ی': {'ﯼ', 'ﯽ', 'ﯾ', 'ﯿ'},
+}
+
+// arabicLamAlef - the isolated and final forms of the lam-alef ligatures
+var arabicLamAlef = map[rune][2]rune{
+	'آ': {'ﻵ', 'ﻶ'},
+	'أ': {'ﻷ', 'ﻸ'},
+	'إ': {'ﻹ', 'ﻺ'},
+	'ا': {'ﻻ', 'ﻼ'},
+}
+
+// Shape returns the text with the presentation forms of the Arabic letters
+func (shaper ArabicShaper) Shape(text string) string {
+	runes := []rune(text)
+	// transparent returns true for the combining marks (harakat) skipped by the joining
+	transparent := func(r rune) bool {
+		return unicode.Is(unicode.Mn, r)
+	}
+	joinsLeft := func(r rune) bool {
+		forms, found := arabicForms[r]
+		return found && forms[2] != 0
+	}
+	joinsRight := func(r rune) bool {
+		forms, found := arabicForms[r]
+		return found && forms[1] != 0
+	}
+	neighbour := func(index, step int) rune {
+		for index += step; index >= 0 && index < len(runes); index += step {
+			if !transparent(runes[index]) {
+				return runes[index]
+			}
+		}
+		return 0
+	}
+	var shaped strings.Builder
+	for index := 0; index < len(runes); index++ {
+		forms, found := arabicForms[runes[index]]
+		if !found {
+			shaped.WriteRune(runes[index])
+			continue
+		}
+		joinPrev := joinsRight(runes[index]) && joinsLeft(neighbour(index, -1))
+		if runes[index] == 'ل' && index+1 < len(runes) {
+			if ligature, found := arabicLamAlef[runes[index+1]]; found {
+				if joinPrev {
+					shaped.WriteRune(ligature[1])
+				} else {
+					shaped.WriteRune(ligature[0])
+				}
+				index++
+				continue
+			}
+		}
+		joinNext := joinsLeft(runes[index]) && joinsRight(neighbour(index, 1))
+		switch {
+		case joinPrev && joinNext:
+			shaped.WriteRune(forms[3])
+		case joinPrev:
+			shaped.WriteRune(forms[1])
+		case joinNext:
+			shaped.WriteRune(forms[2])
+		default:
+			shaped.WriteRune(forms[0])
+		}
+	}
+	return shaped.String()
+}
+
+/*
+IndicShaper - the built-in Shaper of the Indic scripts. The pre-base vowel signs (e.g. the Devanagari
+vowel sign I) are moved before their consonant cluster (the consonants joined by the virama sign), and
+the two-part vowel signs (e.g. the Bengali vowel sign O) are split around the cluster.
+
+The conjunct shaping of the OpenType fonts is not provided: the clusters are printed consonant by consonant
+with the visible virama sign. The optional Ligatures map is a plain text substitution: it replaces the listed
+clusters with a single character, e.g. a private use area code point of a conjunct glyph of the used font.
+The pre-base vowel signs are placed before the replaced character.
+
+Example:
+
+	rpt.SetShaper(report.IndicShaper{Ligatures: map[string]rune{"क्ष": '\uE000'}})
+*/
+type IndicShaper struct {
+	Ligatures map[string]rune
+}
+
+// indicPreBase - the pre-base vowel signs of the Indic scripts
+var indicPreBase = map[rune]bool{
+	'\u093F': true, '\u09BF': true, '\u09C7': true, '\u09C8': true, '\u0A3F': true, '\u0ABF': true,
+	'\u0B47': true, '\u0BC6': true, '\u0BC7': true, '\u0BC8': true, '\u0D46': true, '\u0D47': true,
+	'\u0D48': true, '\u0DD9': true, '\u0DDA': true, '\u0DDB': true,
+}
+
+// indicSplit - the pre-base and the post-base parts of the two-part vowel signs
+var indicSplit = map[rune][2]rune{
+	'\u09CB': {'\u09C7', '\u09BE'}, '\u09CC': {'\u09C7', '\u09D7'},
+	'\u0B48': {'\u0B47', '\u0B56'}, '\u0B4B': {'\u0B47', '\u0B3E'}, '\u0B4C': {'\u0B47', '\u0B57'},
+	'\u0BCA': {'\u0BC6', '\u0BBE'}, '\u0BCB': {'\u0BC7', '\u0BBE'}, '\u0BCC': {'\u0BC6', '\u0BD7'},
+	'\u0D4A': {'\u0D46', '\u0D3E'}, '\u0D4B': {'\u0D47', '\u0D3E'}, '\u0D4C': {'\u0D46', '\u0D57'},
+}
+
+// indicVirama - the virama (halant) signs joining the consonants of a cluster
+var indicVirama = map[rune]bool{
+	'\u094D': true, '\u09CD': true, '\u0A4D': true, '\u0ACD': true, '\u0B4D': true, '\u0BCD': true,
+	'\u0C4D': true, '\u0CCD': true, '\u0D4D': true, '\u0DCA': true,
+}
+
+// indicNukta - the nukta signs modifying the preceding consonant
+var indicNukta = map[rune]bool{
+	'\u093C': true, '\u09BC': true, '\u0A3C': true, '\u0ABC': true, '\u0B3C': true, '\u0CBC': true,
+}
+
+// Shape returns the text with the substituted Ligatures and the reordered vowel signs
+func (shaper IndicShaper) Shape(text string) string {
+	if shaper.Ligatures != nil {
+		// the longest clusters are replaced first
+		clusters := make([]string, 0, len(shaper.Ligatures))
+		for cluster := range shaper.Ligatures {
+			clusters = append(clusters, cluster)
+		}
+		sort.Slice(clusters, func(a, b int) bool {
+			return len(clusters[a]) > len(clusters[b])
+		})
+		pairs := make([]string, 0, 2*len(clusters))
+		for _, cluster := range clusters {
+			pairs = append(pairs, cluster, string(shaper.Ligatures[cluster]))
+		}
+		text = strings.NewReplacer(pairs...).Replace(text)
+	}
+	runes := make([]rune, 0, len(text))
+	for _, r := range text {
+		if parts, found := indicSplit[r]; found {
+			runes = append(runes, parts[0], parts[1])
+			continue
+		}
+		runes = append(runes, r)
+	}
+	for index := 1; index < len(runes); index++ {
+		if !indicPreBase[runes[index]] {
+			continue
+		}
+		// the last consonant (with its nukta sign) or the replaced conjunct of the cluster
+		base := index - 1
+		if indicNukta[runes[base]] && base > 0 {
+			base--
+		}
+		if !indicBase(runes[base]) {
+			continue
+		}
+		// the preceding consonants joined by the virama signs
+		for base > 1 && indicVirama[runes[base-1]] {
+			prev := base - 2
+			if indicNukta[runes[prev]] && prev > 0 {
+				prev--
+			}
+			if !indicBase(runes[prev]) {
+				break
+			}
+			base = prev
+		}
+		vowel := runes[index]
+		copy(runes[base+1:index+1], runes[base:index])
+		runes[base] = vowel
+	}
+	return string(runes)
+}
+
+// indicBase returns true if the character can be the base of a cluster: a letter or a replaced conjunct
+func indicBase(r rune) bool {
+	return unicode.IsLetter(r) || unicode.Is(unicode.Co, r)
+}